@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachmentName string
+	attachmentDir  string
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach KEY FILE...",
+	Short: "Upload files as attachments to an issue",
+	Long: `Upload one or more files as attachments to an issue.
+
+Examples:
+  jira attach MUP-123 screenshot.png
+  jira attach MUP-123 logs/*.log`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		attachments, err := client.AddAttachments(cmd.Context(), key, args[1:])
+		if err != nil {
+			return fmt.Errorf("uploading attachments: %w", err)
+		}
+
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+		return f.FormatAttachments(os.Stdout, key, attachments)
+	},
+}
+
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments KEY",
+	Short: "List the attachments on an issue",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		attachments, err := client.GetAttachments(cmd.Context(), key)
+		if err != nil {
+			return fmt.Errorf("listing attachments: %w", err)
+		}
+
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+		return f.FormatAttachments(os.Stdout, key, attachments)
+	},
+}
+
+var attachmentsDownloadCmd = &cobra.Command{
+	Use:   "download KEY",
+	Short: "Download the attachments on an issue",
+	Long: `Download the attachments on an issue into --dir (default the current
+directory). --name selects attachments by file name using a shell pattern.
+Files are overwritten if they exist; attachments sharing a file name are
+saved with their ID as prefix.
+
+Examples:
+  jira attachments download MUP-123
+  jira attachments download MUP-123 --name "*.log" --dir ./logs`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		attachments, err := client.GetAttachments(cmd.Context(), key)
+		if err != nil {
+			return fmt.Errorf("listing attachments: %w", err)
+		}
+
+		var selected []jira.Attachment
+		names := map[string]int{}
+		for _, a := range attachments {
+			if attachmentName != "" {
+				ok, err := path.Match(attachmentName, a.Filename)
+				if err != nil {
+					return fmt.Errorf("invalid --name pattern: %w", err)
+				}
+				if !ok {
+					continue
+				}
+			}
+			selected = append(selected, a)
+			names[a.Filename]++
+		}
+		if len(selected) == 0 {
+			if attachmentName == "" {
+				return fmt.Errorf("%s has no attachments", key)
+			}
+			return fmt.Errorf("no attachments on %s match %q", key, attachmentName)
+		}
+
+		if err := os.MkdirAll(attachmentDir, 0o755); err != nil {
+			return err
+		}
+		for _, a := range selected {
+			name := filepath.Base(a.Filename)
+			if names[a.Filename] > 1 {
+				name = a.ID + "-" + name
+			}
+			target := filepath.Join(attachmentDir, name)
+			if err := downloadAttachment(cmd, client, a, target); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Downloaded %s (%d bytes)\n", target, a.Size)
+		}
+		return nil
+	},
+}
+
+// downloadAttachment saves a to target, removing the partial file on error.
+func downloadAttachment(cmd *cobra.Command, client *jira.Client, a jira.Attachment, target string) error {
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if err := client.DownloadAttachment(cmd.Context(), a, f); err != nil {
+		f.Close()
+		os.Remove(target)
+		return fmt.Errorf("downloading %s: %w", a.Filename, err)
+	}
+	return f.Close()
+}
+
+func init() {
+	attachmentsDownloadCmd.Flags().StringVar(&attachmentName, "name", "", "Only download attachments whose file name matches this pattern, e.g. \"*.log\"")
+	attachmentsDownloadCmd.Flags().StringVar(&attachmentDir, "dir", ".", "Directory to save attachments in")
+
+	attachmentsCmd.AddCommand(attachmentsDownloadCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(attachmentsCmd)
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/bentsolheim/jira-cli/internal/jira"
@@ -19,52 +21,124 @@ var authCmd = &cobra.Command{
 
 var authStoreCmd = &cobra.Command{
 	Use:   "store",
-	Short: "Store a Personal Access Token in the macOS Keychain",
+	Short: "Store a Personal Access Token or Cloud API token in the credential store",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var token string
+		token, err := readSecret(fmt.Sprintf("Enter your Jira PAT or API token for %s: ", jiraURL))
+		if err != nil {
+			return err
+		}
+		if err := keychain.StorePAT(jiraURL, token); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Token stored successfully (backend: %s).\n", keychain.Backend())
+		return nil
+	},
+}
+
+var loginMethod string
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with an auth method and store its secret",
+	Long: `Log in with one of the supported auth methods and, once the credentials
+are verified against Jira, store the resulting secret in the credential
+store and record the method and its settings in the active profile (created
+as by 'jira config set' if needed).
+
+Methods:
+  bearer  Personal Access Token, prompted for (Server/Data Center)
+  basic   Password for --username, or API token for --email on Jira Cloud
+  cookie  Password for --username, exchanged for a session cookie; only the
+          cookie is stored, so log in again when the session expires
+  oauth   OAuth 1.0a through an application link with an RSA key pair,
+          given by --oauth-consumer-key and --oauth-private-key; you
+          authorize access in the browser and paste the verification code
 
-		if term.IsTerminal(int(os.Stdin.Fd())) {
-			fmt.Fprintf(os.Stderr, "Enter your Jira PAT for %s: ", jiraURL)
-			raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+Examples:
+  jira auth login --method basic --username jdoe
+  jira auth login --method cookie --username jdoe
+  jira auth login --method oauth --oauth-consumer-key jira-cli --oauth-private-key ~/.jira/oauth.pem`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		method := loginMethod
+		if method == "" {
+			method = resolvedAuthMethod()
+		}
+		if err := jira.ValidAuthMethod(method); err != nil {
+			return err
+		}
+
+		var secret string
+		var err error
+		switch method {
+		case jira.AuthBearer:
+			secret, err = readSecret(fmt.Sprintf("Enter your Jira PAT for %s: ", jiraURL))
+		case jira.AuthBasic:
+			user := basicUsername()
+			if user == "" {
+				return fmt.Errorf("basic auth needs --username, or --email for Jira Cloud")
+			}
+			secret, err = readSecret(fmt.Sprintf("Password or API token for %s at %s: ", user, jiraURL))
+		case jira.AuthCookie:
+			if username == "" {
+				return fmt.Errorf("cookie auth needs --username")
+			}
+			password, err := readSecret(fmt.Sprintf("Password for %s at %s: ", username, jiraURL))
 			if err != nil {
-				return fmt.Errorf("reading token: %w", err)
+				return err
 			}
-			fmt.Fprintln(os.Stderr) // newline after hidden input
-			token = string(raw)
-		} else {
-			// Support piped input: echo "token" | jira auth store
-			reader := bufio.NewReader(os.Stdin)
-			raw, err := reader.ReadString('\n')
+			client, err := newClientWith(nil)
 			if err != nil {
-				return fmt.Errorf("reading token from stdin: %w", err)
+				return err
+			}
+			if secret, err = client.CreateSession(ctx, username, password); err != nil {
+				return err
 			}
-			token = raw
+		case jira.AuthOAuth:
+			secret, err = oauthLogin(ctx)
+		}
+		if err != nil {
+			return err
 		}
 
-		token = strings.TrimSpace(token)
-		if token == "" {
-			return fmt.Errorf("token cannot be empty")
+		// Verify the new credentials before they replace the stored ones.
+		authMethod = method
+		auth, err := newAuthenticator(secret)
+		if err != nil {
+			return err
+		}
+		client, err := newClientWith(auth)
+		if err != nil {
+			return err
+		}
+		user, err := client.Myself(ctx)
+		if err != nil {
+			return fmt.Errorf("authentication failed, nothing was saved: %w", err)
 		}
 
-		if err := keychain.StorePAT(jiraURL, token); err != nil {
+		if err := keychain.StorePAT(jiraURL, secret); err != nil {
 			return err
 		}
-		fmt.Fprintln(os.Stderr, "PAT stored successfully in Keychain.")
+		profile, err := saveAuthSettings(method)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Logged in with %s auth (backend: %s, profile: %s).\n", method, keychain.Backend(), profile)
+		fmt.Fprintf(os.Stderr, "Authenticated as: %s (%s)\n", user.DisplayName, user.EmailAddress)
 		return nil
 	},
 }
 
 var authTestCmd = &cobra.Command{
 	Use:   "test",
-	Short: "Verify that the stored PAT works against the Jira API",
+	Short: "Verify that the stored credentials work against the Jira API",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		token, err := keychain.GetPAT(jiraURL)
+		client, err := newClient()
 		if err != nil {
 			return err
 		}
-
-		client := jira.NewClient(jiraURL, token, verbose)
-		user, err := client.Myself()
+		user, err := client.Myself(cmd.Context())
 		if err != nil {
 			return fmt.Errorf("authentication failed: %w", err)
 		}
@@ -76,17 +150,163 @@ var authTestCmd = &cobra.Command{
 
 var authDeleteCmd = &cobra.Command{
 	Use:   "delete",
-	Short: "Remove the stored PAT from the macOS Keychain",
+	Short: "Remove the stored secret from the credential store",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := keychain.DeletePAT(jiraURL); err != nil {
 			return err
 		}
-		fmt.Fprintln(os.Stderr, "PAT deleted from Keychain.")
+		fmt.Fprintf(os.Stderr, "Secret deleted (backend: %s).\n", keychain.Backend())
 		return nil
 	},
 }
 
+// readSecret reads a secret from the terminal without echo, or a line from
+// piped stdin.
+func readSecret(prompt string) (string, error) {
+	var secret string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr, prompt)
+		raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return "", fmt.Errorf("reading secret: %w", err)
+		}
+		fmt.Fprintln(os.Stderr) // newline after hidden input
+		secret = string(raw)
+	} else {
+		// Support piped input: echo "token" | jira auth store
+		reader := bufio.NewReader(os.Stdin)
+		raw, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("reading secret from stdin: %w", err)
+		}
+		secret = raw
+	}
+
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("secret cannot be empty")
+	}
+	return secret, nil
+}
+
+// oauthLogin runs the OAuth 1.0a dance and returns the access token.
+func oauthLogin(ctx context.Context) (string, error) {
+	if oauthConsumer == "" || oauthKeyFile == "" {
+		return "", fmt.Errorf("oauth auth needs --oauth-consumer-key and --oauth-private-key")
+	}
+	key, err := jira.LoadPrivateKey(oauthKeyFile)
+	if err != nil {
+		return "", err
+	}
+	client, err := newClientWith(nil)
+	if err != nil {
+		return "", err
+	}
+	requestToken, err := client.OAuthRequestToken(ctx, oauthConsumer, key)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(os.Stderr, "Open this URL, allow access, and enter the verification code Jira shows:\n  %s\n", client.OAuthAuthorizeURL(requestToken))
+	fmt.Fprint(os.Stderr, "Verification code: ")
+	verifier, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && verifier == "" {
+		return "", fmt.Errorf("reading verification code: %w", err)
+	}
+	return client.OAuthAccessToken(ctx, oauthConsumer, key, requestToken, strings.TrimSpace(verifier))
+}
+
+// resolvedAuthMethod returns --auth-method, defaulting to basic auth when an
+// email is set (Jira Cloud API tokens) and to a bearer PAT otherwise.
+func resolvedAuthMethod() string {
+	switch {
+	case authMethod != "":
+		return authMethod
+	case email != "":
+		return jira.AuthBasic
+	default:
+		return jira.AuthBearer
+	}
+}
+
+// basicUsername returns the basic auth username: --username, or the Jira
+// Cloud account email.
+func basicUsername() string {
+	if username != "" {
+		return username
+	}
+	return email
+}
+
+// newAuthenticator builds the authenticator for the resolved auth method
+// around the stored secret.
+func newAuthenticator(secret string) (jira.Authenticator, error) {
+	method := resolvedAuthMethod()
+	switch method {
+	case jira.AuthBearer:
+		return &jira.BearerAuth{Token: secret}, nil
+	case jira.AuthBasic:
+		user := basicUsername()
+		if user == "" {
+			return nil, fmt.Errorf("basic auth needs --username, or --email for Jira Cloud")
+		}
+		return &jira.BasicAuth{Username: user, Password: secret}, nil
+	case jira.AuthCookie:
+		return &jira.CookieAuth{Cookie: secret}, nil
+	case jira.AuthOAuth:
+		if oauthConsumer == "" || oauthKeyFile == "" {
+			return nil, fmt.Errorf("oauth auth needs --oauth-consumer-key and --oauth-private-key")
+		}
+		key, err := jira.LoadPrivateKey(oauthKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &jira.OAuth1{ConsumerKey: oauthConsumer, PrivateKey: key, Token: secret}, nil
+	}
+	return nil, jira.ValidAuthMethod(method)
+}
+
+// saveAuthSettings records the auth method and its non-secret settings in
+// the active profile, creating it as 'config set' does, and returns the
+// profile name.
+func saveAuthSettings(method string) (string, error) {
+	name := activeProfileName()
+	if name == "" {
+		name = defaultProfileName
+	}
+	if cfg.CurrentProfile == "" {
+		cfg.CurrentProfile = name
+	}
+	p := cfg.EnsureProfile(name)
+
+	settings := map[string]string{
+		"auth-method":        method,
+		"username":           username,
+		"email":              email,
+		"oauth-consumer-key": oauthConsumer,
+		"oauth-private-key":  oauthKeyFile,
+	}
+	if p.URL == "" {
+		settings["url"] = jiraURL
+	}
+	if method == jira.AuthOAuth {
+		if abs, err := filepath.Abs(oauthKeyFile); err == nil {
+			settings["oauth-private-key"] = abs
+		}
+	}
+	for key, value := range settings {
+		if value == "" {
+			continue
+		}
+		if err := p.Set(key, value); err != nil {
+			return "", err
+		}
+	}
+	return name, cfg.Save()
+}
+
 func init() {
+	authLoginCmd.Flags().StringVar(&loginMethod, "method", "", "Auth method: bearer, basic, cookie or oauth (default: --auth-method)")
+	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authStoreCmd)
 	authCmd.AddCommand(authTestCmd)
 	authCmd.AddCommand(authDeleteCmd)
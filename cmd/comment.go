@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/bentsolheim/jira-cli/internal/markup"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	commentVisibilityRole  string
+	commentVisibilityGroup string
+	commentMaxResults      int
+	commentAll             bool
+	commentMarkdown        bool
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Add, edit, delete and list issue comments",
+}
+
+// commentBody returns the comment text from the argument at index i, piped
+// stdin, or $EDITOR (pre-filled with initial), in that order.
+func commentBody(args []string, i int, initial string) (string, error) {
+	var body string
+	switch {
+	case len(args) > i:
+		body = strings.Join(args[i:], " ")
+	case !term.IsTerminal(int(os.Stdin.Fd())):
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading stdin: %w", err)
+		}
+		body = string(data)
+	default:
+		edited, err := editText(initial)
+		if err != nil {
+			return "", err
+		}
+		body = edited
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("comment body cannot be empty")
+	}
+	return body, nil
+}
+
+// editText opens $VISUAL or $EDITOR (default vi) on a temporary file holding
+// initial and returns the saved contents.
+func editText(initial string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := os.CreateTemp("", "jira-comment-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	f.Close()
+
+	// Run through the shell so EDITOR may contain arguments, e.g. "code --wait".
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", f.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running editor %q: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", fmt.Errorf("reading temp file: %w", err)
+	}
+	return string(data), nil
+}
+
+// commentVisibility builds the visibility restriction from the flags, if any.
+func commentVisibility() *jira.Visibility {
+	switch {
+	case commentVisibilityRole != "":
+		return &jira.Visibility{Type: "role", Value: commentVisibilityRole}
+	case commentVisibilityGroup != "":
+		return &jira.Visibility{Type: "group", Value: commentVisibilityGroup}
+	}
+	return nil
+}
+
+var commentAddCmd = &cobra.Command{
+	Use:   "add KEY [BODY]",
+	Short: "Add a comment to an issue",
+	Long: `Add a comment to an issue. The body is taken from the arguments,
+from stdin when piped, or from $EDITOR otherwise.
+
+Examples:
+  jira comment add MUP-123 "Root cause is the expired certificate"
+  cat findings.md | jira comment add MUP-123 --markdown
+  jira comment add MUP-123 --visibility-role Developers`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		body, err := commentBody(args, 1, "")
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		comment, err := client.AddComment(cmd.Context(), key, &jira.CommentRequest{
+			Body:       body,
+			Visibility: commentVisibility(),
+			Markdown:   commentMarkdown,
+		})
+		if err != nil {
+			return fmt.Errorf("adding comment: %w", err)
+		}
+
+		return formatSingleComment(key, comment)
+	},
+}
+
+var commentEditCmd = &cobra.Command{
+	Use:   "edit KEY ID [BODY]",
+	Short: "Replace the body of a comment",
+	Long: `Replace the body of a comment. The new body is taken from the
+arguments, from stdin when piped, or from $EDITOR pre-filled with the
+current body.
+
+Without --visibility-role or --visibility-group, the existing visibility
+is kept.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, id := args[0], args[1]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		current, err := client.GetComment(cmd.Context(), key, id)
+		if err != nil {
+			return fmt.Errorf("fetching comment %s: %w", id, err)
+		}
+
+		// Pre-fill the editor in the format the new body is read in.
+		initial := current.Body
+		switch {
+		case commentMarkdown && !current.Markdown:
+			initial = markup.WikiToMarkdown(initial)
+		case !commentMarkdown && current.Markdown:
+			initial = markup.MarkdownToWiki(initial)
+		}
+		body, err := commentBody(args, 2, initial)
+		if err != nil {
+			return err
+		}
+		visibility := commentVisibility()
+		if visibility == nil {
+			visibility = current.Visibility
+		}
+
+		comment, err := client.UpdateComment(cmd.Context(), key, id, &jira.CommentRequest{
+			Body:       body,
+			Visibility: visibility,
+			Markdown:   commentMarkdown,
+		})
+		if err != nil {
+			return fmt.Errorf("editing comment: %w", err)
+		}
+
+		return formatSingleComment(key, comment)
+	},
+}
+
+var commentDeleteCmd = &cobra.Command{
+	Use:   "delete KEY ID",
+	Short: "Delete a comment",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteComment(cmd.Context(), args[0], args[1]); err != nil {
+			return fmt.Errorf("deleting comment: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Comment %s deleted from %s.\n", args[1], args[0])
+		return nil
+	},
+}
+
+var commentListCmd = &cobra.Command{
+	Use:   "list KEY",
+	Short: "List the comments on an issue",
+	Long: `List the comments on an issue, oldest first. Unlike 'jira issue',
+this pages beyond the comments embedded in the issue.
+
+Examples:
+  jira comment list MUP-123
+  jira comment list MUP-123 --all -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		var comments *jira.Comments
+		if commentAll {
+			comments, err = client.GetAllComments(cmd.Context(), key, 0)
+		} else {
+			comments, err = client.GetAllComments(cmd.Context(), key, commentMaxResults)
+		}
+		if err != nil {
+			return fmt.Errorf("listing comments: %w", err)
+		}
+
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+		return f.FormatComments(os.Stdout, key, comments)
+	},
+}
+
+// formatSingleComment writes one comment through the selected formatter.
+func formatSingleComment(key string, comment *jira.Comment) error {
+	f, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	return f.FormatComments(os.Stdout, key, &jira.Comments{
+		Comments: []jira.Comment{*comment},
+		Total:    1,
+	})
+}
+
+func init() {
+	for _, c := range []*cobra.Command{commentAddCmd, commentEditCmd} {
+		c.Flags().StringVar(&commentVisibilityRole, "visibility-role", "", "Restrict the comment to a project role")
+		c.Flags().StringVar(&commentVisibilityGroup, "visibility-group", "", "Restrict the comment to a group")
+		c.MarkFlagsMutuallyExclusive("visibility-role", "visibility-group")
+		c.Flags().BoolVar(&commentMarkdown, "markdown", false, "The body is Markdown rather than Jira wiki markup")
+	}
+	commentListCmd.Flags().IntVar(&commentMaxResults, "max-results", 50, "Maximum number of comments to return")
+	commentListCmd.Flags().BoolVar(&commentAll, "all", false, "Fetch all comments, following pagination")
+	commentListCmd.MarkFlagsMutuallyExclusive("max-results", "all")
+
+	commentCmd.AddCommand(commentAddCmd)
+	commentCmd.AddCommand(commentEditCmd)
+	commentCmd.AddCommand(commentDeleteCmd)
+	commentCmd.AddCommand(commentListCmd)
+	rootCmd.AddCommand(commentCmd)
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultProfileName is used by 'config set' when no profile exists yet.
+const defaultProfileName = "default"
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage configuration profiles",
+	Long: fmt.Sprintf(`Manage named configuration profiles stored in %s.
+
+Each profile describes one Jira instance. The active profile is chosen by
+--profile, then JIRA_PROFILE, then the current-profile set by 'config use'.
+Command-line flags and environment variables override profile settings.
+
+Keys:
+  url                 Jira base URL
+  deployment          auto (detect), server (Server/Data Center) or cloud
+  email               Account email for Jira Cloud API token auth
+  auth-method         bearer (PAT), basic, cookie or oauth; set by 'auth login'
+  username            Username for basic and cookie auth
+  oauth-consumer-key  Consumer key of the OAuth application link
+  oauth-private-key   Path to the PEM private key of the OAuth application link
+  project             Default project for 'jira ls'
+  closed-statuses     Comma-separated statuses treated as closed
+  output              Default output format: markdown, json, text
+  auth-backend        Credential store: auto, keychain, secret-service, pass, file, env
+  credential-helper   External credential helper command
+  retries             Retries for throttled or unavailable responses
+  retry-wait          Initial backoff between retries, e.g. 500ms
+  retry-max-wait      Upper bound for backoff and Retry-After waits, e.g. 1m
+  retry-unsafe        true to also retry non-idempotent requests (POST)
+  timeout             Command timeout, e.g. 2m
+  epic-children       How to find the issues in an epic: epic-link, parent,
+                      none, or a JQL template such as
+                      "parent = {key} OR cf[10761] = {key}"
+  ca-file             PEM bundle of extra trusted CAs, e.g. an internal CA
+  client-cert         PEM client certificate for mutual TLS
+  client-key          PEM key for client-cert, if not in the same file
+  insecure            true to skip TLS certificate verification (unsafe)
+  proxy               Proxy URL, overriding HTTP_PROXY and HTTPS_PROXY
+  no-proxy            Comma-separated hosts to reach without the proxy
+  fields.<name>       Field ID for epic-link, epic-name, parent-link,
+                      story-points or sprint, e.g. customfield_10761.
+                      Only needed when discovery by field name fails.
+
+Examples:
+  jira config set url https://jira.example.com --profile work
+  jira config set project MUP --profile work
+  jira config use work
+  jira config get url
+  jira config list`, config.Path()),
+	// Config commands must work before any profile exists, so they skip
+	// the root hook that resolves and applies the active profile.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		c, err := config.Load()
+		if err != nil {
+			return err
+		}
+		cfg = c
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get KEY",
+	Short: "Print a setting from the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := activeProfileName()
+		if name == "" {
+			return fmt.Errorf("no profile selected: use --profile, JIRA_PROFILE or 'jira config use'")
+		}
+		p := cfg.Profile(name)
+		if p == nil {
+			return fmt.Errorf("profile %q does not exist", name)
+		}
+		value, err := p.Get(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set KEY VALUE",
+	Short: "Set a setting in the active profile, creating it if needed",
+	Long: `Set a setting in the active profile, creating the profile if needed.
+An empty VALUE unsets the key. If no profile is selected and none exists,
+a profile named "default" is created and made current.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := activeProfileName()
+		if name == "" {
+			name = defaultProfileName
+		}
+		if cfg.CurrentProfile == "" {
+			cfg.CurrentProfile = name
+		}
+
+		if err := cfg.EnsureProfile(name).Set(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Set %s in profile %q.\n", args[0], name)
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles and their settings",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(cfg.Profiles) == 0 {
+			fmt.Fprintf(os.Stderr, "No profiles configured in %s.\n", config.Path())
+			return nil
+		}
+
+		active := activeProfileName()
+		var b strings.Builder
+		for _, name := range cfg.ProfileNames() {
+			marker := " "
+			if name == active {
+				marker = "*"
+			}
+			b.WriteString(fmt.Sprintf("%s %s\n", marker, name))
+			p := cfg.Profile(name)
+			for _, key := range p.SetKeys() {
+				value, _ := p.Get(key)
+				b.WriteString(fmt.Sprintf("    %s: %s\n", key, value))
+			}
+		}
+		_, err := fmt.Fprint(os.Stdout, b.String())
+		return err
+	},
+}
+
+var configUseCmd = &cobra.Command{
+	Use:   "use PROFILE",
+	Short: "Make PROFILE the current profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if cfg.Profile(name) == nil {
+			return fmt.Errorf("profile %q does not exist (create it with 'jira config set --profile %s url URL')", name, name)
+		}
+		cfg.CurrentProfile = name
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Switched to profile %q.\n", name)
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configUseCmd)
+	rootCmd.AddCommand(configCmd)
+}
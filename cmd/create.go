@@ -1,17 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 
-	"github.com/bentsolheim/jira-cli/internal/formatter"
 	"github.com/bentsolheim/jira-cli/internal/jira"
-	"github.com/bentsolheim/jira-cli/internal/keychain"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
+var createMarkdown bool
+
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new Jira issue from YAML input",
@@ -21,12 +22,27 @@ Supported fields:
   project:     Project key (e.g., "MUP")
   summary:     Issue summary (required)
   description: Issue description
+  descriptionFormat: wiki (default) or markdown for the rich text fields, same as --markdown
   type:        Issue type name (e.g., "Task", "Bug", "Story", "Epos")
   labels:      List of labels
   epicLink:    Epic issue key to link to (for stories/tasks)
   epicName:    Epic short name (required when type is Epos/Epic)
   parent:      Parent issue key (for subtasks only)
   parentLink:  Parent Link for Epic → Del-leveranse hierarchy
+  storyPoints: Story point estimate
+  assignee:    Username, email address, or "me"
+  priority:    Priority name (e.g., "High")
+  components:  List of component names
+  fixVersions: List of fix version names
+  affectsVersions: List of affected version names
+  dueDate:     Due date (YYYY-MM-DD)
+  environment: Environment description
+  originalEstimate: Original estimate (e.g., "2d 4h")
+  fields:      Map of any other field, by name or ID, to its value
+
+Custom fields (Epic Link, Epic Name, Parent Link, Story Points) are located
+by name on the Jira instance; pin their IDs with 'jira config set fields.<name>'
+if discovery fails.
 
 Example YAML:
   project: MUP
@@ -37,6 +53,15 @@ Example YAML:
     - security
     - urgent
   epicLink: MUP-123
+  fields:
+    Team: Platform            # single select
+    Fix Version/s: [2.1]      # versions
+    Due Date: 2026-03-01      # date
+    customfield_10500: 42     # number, by ID
+
+Values in fields are converted to the shape Jira expects for the field's
+type (select lists, multi-selects, users, dates, numbers, versions). A map
+value is sent unchanged.
 
 Usage:
   echo 'project: MUP
@@ -56,6 +81,10 @@ Usage:
 		if input.Summary == "" {
 			return fmt.Errorf("summary is required")
 		}
+		markdown, err := richTextMarkdown(&input, createMarkdown)
+		if err != nil {
+			return err
+		}
 		if input.Project == "" {
 			return fmt.Errorf("project is required")
 		}
@@ -65,33 +94,58 @@ Usage:
 
 		req := &jira.IssueCreateRequest{
 			Fields: jira.IssueCreateFields{
-				Project:    &jira.ProjectRef{Key: input.Project},
-				Summary:    input.Summary,
+				Project:     &jira.ProjectRef{Key: input.Project},
+				Summary:     input.Summary,
 				Description: input.Description,
-				IssueType:  &jira.TypeRef{Name: input.Type},
-				Labels:     input.Labels,
-				EpicLink:   input.EpicLink,
-				EpicName:   input.EpicName,
-				ParentLink: input.ParentLink,
+				IssueType:   &jira.TypeRef{Name: input.Type},
+				Labels:      input.Labels,
+				EpicLink:    input.EpicLink,
+				EpicName:    input.EpicName,
+				ParentLink:  input.ParentLink,
+				StoryPoints: input.StoryPoints,
+				Components:  jira.NameRefs(input.Components),
+				FixVersions: jira.NameRefs(input.FixVersions),
+				Versions:    jira.NameRefs(input.AffectsVersions),
+				DueDate:     input.DueDate,
+				Environment: input.Environment,
+				Markdown:    markdown,
 			},
 		}
 
 		if input.Parent != "" {
 			req.Fields.Parent = &jira.IssueRef{Key: input.Parent}
 		}
+		if input.Priority != "" {
+			req.Fields.Priority = &jira.NameRef{Name: input.Priority}
+		}
+		if input.OriginalEstimate != "" {
+			req.Fields.TimeTracking = &jira.TimeTracking{OriginalEstimate: input.OriginalEstimate}
+		}
 
-		token, err := keychain.GetPAT(jiraURL)
+		client, err := newClient()
 		if err != nil {
 			return err
 		}
 
-		client := jira.NewClient(jiraURL, token, verbose)
-		issue, err := client.CreateIssue(req)
+		if input.Assignee != "" {
+			if req.Fields.Assignee, err = resolveAssignee(cmd.Context(), client, input.Assignee); err != nil {
+				return err
+			}
+		}
+
+		if len(input.Fields) > 0 {
+			custom, err := client.ConvertFieldValues(cmd.Context(), input.Fields)
+			if err != nil {
+				return err
+			}
+			req.Fields.Custom = custom
+		}
+		issue, err := client.CreateIssue(cmd.Context(), req)
 		if err != nil {
 			return fmt.Errorf("creating issue: %w", err)
 		}
 
-		f, err := formatter.New(outputFormat, jiraURL)
+		f, err := newFormatter()
 		if err != nil {
 			return err
 		}
@@ -100,6 +154,32 @@ Usage:
 	},
 }
 
+// resolveAssignee looks up an assignee given as username, email address or "me".
+func resolveAssignee(ctx context.Context, client *jira.Client, query string) (*jira.UserRef, error) {
+	user, err := client.FindUser(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("assignee: %w", err)
+	}
+	return &jira.UserRef{Name: user.Name, AccountID: user.AccountID}, nil
+}
+
+// richTextMarkdown reports whether the description and other rich text
+// fields are Markdown: when descriptionFormat is markdown, or when it is
+// unset and markdown is true.
+func richTextMarkdown(input *jira.IssueInput, markdown bool) (bool, error) {
+	switch input.DescriptionFormat {
+	case "":
+	case "wiki":
+		markdown = false
+	case "markdown", "md":
+		markdown = true
+	default:
+		return false, fmt.Errorf("descriptionFormat must be wiki or markdown, got %q", input.DescriptionFormat)
+	}
+	return markdown, nil
+}
+
 func init() {
+	createCmd.Flags().BoolVar(&createMarkdown, "markdown", false, "Rich text (description, environment, text area fields) is Markdown rather than Jira wiki markup")
 	rootCmd.AddCommand(createCmd)
 }
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/graph"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphDepth int
+	graphLimit int
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph KEY...|JQL",
+	Short: "Export a dependency graph in DOT or Mermaid",
+	Long: `Crawl issue links, subtasks and the epic/parent hierarchy breadth-first
+from one or more issues, or from the issues matching a JQL query, and print
+the result as a graph. Nodes are colored by status category and edges are
+labelled with the link type. The hierarchy is followed upwards: the issues
+in an epic are not searched for, so start from a query such as
+"Epic Link" = MUP-1 to include them.
+
+Output formats (-o): dot, mermaid, markdown (default; a fenced Mermaid
+block), json, and text (same as dot).
+
+Examples:
+  jira graph MUP-123 -o dot | dot -Tsvg > deps.svg
+  jira graph MUP-123 --depth 3 -o mermaid
+  jira graph "project = MUP AND labels = release-2" --depth 1`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		roots := splitKeys(args)
+		for _, key := range roots {
+			if !issueKeyPattern.MatchString(key) {
+				roots = nil
+				break
+			}
+		}
+		if roots == nil {
+			jql := strings.Join(args, " ")
+			result, err := client.SearchAll(ctx, jql, graphLimit)
+			if err != nil {
+				return fmt.Errorf("search failed: %w", err)
+			}
+			for _, issue := range result.Issues {
+				roots = append(roots, issue.Key)
+			}
+			if len(roots) == 0 {
+				return fmt.Errorf("no issues match %q", jql)
+			}
+		}
+
+		g, err := graph.Crawl(ctx, client.GetIssueWithoutEpicChildren, roots, graphDepth)
+		if err != nil {
+			return err
+		}
+		return graph.Write(os.Stdout, g, outputFormat)
+	},
+}
+
+func init() {
+	graphCmd.Flags().IntVar(&graphDepth, "depth", 2, "How many hops to follow from the starting issues")
+	graphCmd.Flags().IntVar(&graphLimit, "limit", 50, "Maximum number of starting issues from a JQL query")
+	rootCmd.AddCommand(graphCmd)
+}
@@ -1,16 +1,50 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
-	"github.com/bentsolheim/jira-cli/internal/formatter"
+	"github.com/bentsolheim/jira-cli/internal/config"
 	"github.com/bentsolheim/jira-cli/internal/jira"
-	"github.com/bentsolheim/jira-cli/internal/keychain"
 	"github.com/spf13/cobra"
 )
 
+var (
+	issueFields             string
+	issueIncludeAttachments bool
+	issueAttachmentMaxKB    int64
+)
+
+// issueKeyPattern matches an issue key such as PROJ-123.
+var issueKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-[0-9]+$`)
+
+// splitKeys supports both "KEY1 KEY2" and "KEY1,KEY2" styles of listing issue keys.
+func splitKeys(args []string) []string {
+	var keys []string
+	for _, arg := range args {
+		for _, k := range strings.Split(arg, ",") {
+			k = strings.TrimSpace(k)
+			if k != "" {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// lookupFields resolves a comma-separated list of field names or IDs given
+// with --fields. An empty list yields no fields.
+func lookupFields(ctx context.Context, client *jira.Client, list string) ([]jira.Field, error) {
+	names := config.SplitList(list)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return client.FindFields(ctx, names)
+}
+
 var issueCmd = &cobra.Command{
 	Use:   "issue [KEY...]",
 	Short: "Get details of one or more Jira issues",
@@ -21,39 +55,45 @@ Examples:
   jira issue PROJ-123
   jira issue PROJ-123 PROJ-456
   jira issue PROJ-123,PROJ-456,PROJ-789
-  jira issue PROJ-123 -o markdown`,
+  jira issue PROJ-123 -o markdown
+  jira issue PROJ-123 --fields "Story Points,Team,customfield_12345"
+  jira issue PROJ-123 --include-attachments
+
+With --include-attachments, text attachments (logs, stack traces, JSON,
+YAML, ...) up to --attachment-max-kb are downloaded and embedded in the
+Markdown and JSON output. Other attachments are listed by name only.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Support both "KEY1 KEY2" and "KEY1,KEY2" styles
-		var keys []string
-		for _, arg := range args {
-			for _, k := range strings.Split(arg, ",") {
-				k = strings.TrimSpace(k)
-				if k != "" {
-					keys = append(keys, k)
-				}
-			}
-		}
+		keys := splitKeys(args)
 
-		token, err := keychain.GetPAT(jiraURL)
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		f, err := newFormatter()
 		if err != nil {
 			return err
 		}
 
-		client := jira.NewClient(jiraURL, token, verbose)
-		f, err := formatter.New(outputFormat, jiraURL)
+		fields, err := lookupFields(cmd.Context(), client, issueFields)
 		if err != nil {
 			return err
 		}
 
 		for i, key := range keys {
-			issue, err := client.GetIssue(key)
+			issue, err := client.GetIssue(cmd.Context(), key)
 			if err != nil {
 				return fmt.Errorf("failed to get issue %s: %w", key, err)
 			}
+			jira.SetExtraFields(issue, fields)
+			if issueIncludeAttachments {
+				if err := client.LoadAttachmentText(cmd.Context(), issue, issueAttachmentMaxKB*1024); err != nil {
+					return fmt.Errorf("failed to get attachments of %s: %w", key, err)
+				}
+			}
 
 			if i > 0 {
-				fmt.Fprintln(os.Stdout, "\n---\n")
+				fmt.Fprint(os.Stdout, "\n---\n\n")
 			}
 
 			if err := f.FormatIssue(os.Stdout, issue); err != nil {
@@ -66,5 +106,8 @@ Examples:
 }
 
 func init() {
+	issueCmd.Flags().StringVar(&issueFields, "fields", "", "Comma-separated extra fields (names or IDs) to include in the output")
+	issueCmd.Flags().BoolVar(&issueIncludeAttachments, "include-attachments", false, "Embed the content of text attachments in the output")
+	issueCmd.Flags().Int64Var(&issueAttachmentMaxKB, "attachment-max-kb", 256, "Largest attachment to embed with --include-attachments, in KB")
 	rootCmd.AddCommand(issueCmd)
 }
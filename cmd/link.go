@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link KEY1 TYPE KEY2",
+	Short: "Link two issues",
+	Long: `Link two issues. TYPE is a link type name or its outward or inward
+wording as configured in Jira (see 'jira link types'), matched without
+regard to case. Inward wording links the issues in the opposite direction.
+
+Examples:
+  jira link MUP-1 blocks MUP-2
+  jira link MUP-2 "is blocked by" MUP-1       # Same link as above
+  jira link MUP-1 relates MUP-3
+  jira link types
+  jira link url MUP-1 https://example.com/runbook "Runbook"`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, phrase, to := args[0], args[1], args[2]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		types, err := client.GetLinkTypes(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("fetching link types: %w", err)
+		}
+		lt, reversed, err := jira.FindLinkType(types, phrase)
+		if err != nil {
+			return err
+		}
+		if reversed {
+			from, to = to, from
+		}
+
+		if err := client.LinkIssues(cmd.Context(), from, lt.Name, to); err != nil {
+			return fmt.Errorf("linking issues: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Linked: %s %s %s.\n", from, lt.Outward, to)
+		return nil
+	},
+}
+
+var linkTypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "List the issue link types",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		types, err := client.GetLinkTypes(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("fetching link types: %w", err)
+		}
+
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+		return f.FormatLinkTypes(os.Stdout, types)
+	},
+}
+
+var linkURLCmd = &cobra.Command{
+	Use:   "url KEY URL [TITLE]",
+	Short: "Add a web link to an issue",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, linkURL := args[0], args[1]
+		title := linkURL
+		if len(args) > 2 {
+			title = strings.Join(args[2:], " ")
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		if err := client.AddRemoteLink(cmd.Context(), key, linkURL, title); err != nil {
+			return fmt.Errorf("adding web link: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Added web link %q to %s.\n", title, key)
+		return nil
+	},
+}
+
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink KEY1 KEY2 [TYPE]",
+	Short: "Remove the links between two issues",
+	Long: `Remove the links between two issues, in either direction. With TYPE
+(name or wording, as for 'jira link'), only links of that type are removed.
+
+Examples:
+  jira unlink MUP-1 MUP-2
+  jira unlink MUP-1 MUP-2 blocks`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, other := args[0], args[1]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		var only *jira.IssueLinkType
+		if len(args) > 2 {
+			types, err := client.GetLinkTypes(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("fetching link types: %w", err)
+			}
+			lt, _, err := jira.FindLinkType(types, args[2])
+			if err != nil {
+				return err
+			}
+			only = &lt
+		}
+
+		issue, err := client.GetIssueWithoutEpicChildren(cmd.Context(), key)
+		if err != nil {
+			return fmt.Errorf("failed to get issue %s: %w", key, err)
+		}
+
+		removed := 0
+		for _, link := range issue.Fields.IssueLinks {
+			if only != nil && link.Type.Name != only.Name {
+				continue
+			}
+			var desc string
+			switch {
+			case link.OutwardIssue != nil && strings.EqualFold(link.OutwardIssue.Key, other):
+				desc = fmt.Sprintf("%s %s %s", key, link.Type.Outward, link.OutwardIssue.Key)
+			case link.InwardIssue != nil && strings.EqualFold(link.InwardIssue.Key, other):
+				desc = fmt.Sprintf("%s %s %s", key, link.Type.Inward, link.InwardIssue.Key)
+			default:
+				continue
+			}
+			if err := client.DeleteLink(cmd.Context(), link.ID); err != nil {
+				return fmt.Errorf("removing link %q: %w", desc, err)
+			}
+			fmt.Fprintf(os.Stderr, "Removed: %s.\n", desc)
+			removed++
+		}
+		if removed == 0 {
+			return fmt.Errorf("no matching links between %s and %s", key, other)
+		}
+		return nil
+	},
+}
+
+func init() {
+	linkCmd.AddCommand(linkTypesCmd)
+	linkCmd.AddCommand(linkURLCmd)
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+}
@@ -5,9 +5,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/bentsolheim/jira-cli/internal/formatter"
-	"github.com/bentsolheim/jira-cli/internal/jira"
-	"github.com/bentsolheim/jira-cli/internal/keychain"
+	"github.com/bentsolheim/jira-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -17,30 +15,32 @@ var (
 	lsProject       string
 	lsIncludeClosed bool
 	lsMaxResults    int
+	lsAll           bool
+	lsLimit         int
 	lsSortCreated   bool
 	lsSortUpdated   bool
 )
 
 const defaultClosedStatuses = "Lukket,Utført"
 
+// getDefaultProject returns JIRA_PROJECT, falling back to the profile's project.
 func getDefaultProject() string {
-	return os.Getenv("JIRA_PROJECT")
+	if project := os.Getenv("JIRA_PROJECT"); project != "" {
+		return project
+	}
+	return profile.Project
 }
 
+// getClosedStatuses returns JIRA_CLOSED_STATUSES, falling back to the profile's
+// closed-statuses and then to defaultClosedStatuses.
 func getClosedStatuses() []string {
-	val := os.Getenv("JIRA_CLOSED_STATUSES")
-	if val == "" {
-		val = defaultClosedStatuses
+	if val := os.Getenv("JIRA_CLOSED_STATUSES"); val != "" {
+		return config.SplitList(val)
 	}
-	parts := strings.Split(val, ",")
-	var statuses []string
-	for _, s := range parts {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			statuses = append(statuses, s)
-		}
+	if len(profile.ClosedStatuses) > 0 {
+		return profile.ClosedStatuses
 	}
-	return statuses
+	return config.SplitList(defaultClosedStatuses)
 }
 
 func buildLsJQL(project, text, status, orderBy string, mine, includeClosed bool) string {
@@ -87,9 +87,10 @@ var lsCmd = &cobra.Command{
 	Short: "List open issues in the default project",
 	Long: `List issues that are not closed in the default project.
 
-The default project is read from the JIRA_PROJECT environment variable.
-Closed statuses default to "Lukket, Utført" and can be overridden with
-the JIRA_CLOSED_STATUSES environment variable (comma-separated).
+The default project is read from the JIRA_PROJECT environment variable,
+falling back to the active profile's project. Closed statuses default to
+"Lukket, Utført" and can be overridden with the JIRA_CLOSED_STATUSES
+environment variable (comma-separated) or the profile's closed-statuses.
 
 An optional text argument searches in summary and description fields.
 
@@ -100,7 +101,8 @@ Examples:
   jira ls "terraform" --mine           # My issues matching "terraform"
   jira ls --status "I gang"            # Only issues with status "I gang"
   jira ls --project OTHER              # Override default project
-  jira ls --include-closed             # Include closed/resolved issues`,
+  jira ls --include-closed             # Include closed/resolved issues
+  jira ls --all                        # Every open issue, across all pages`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		project := lsProject
@@ -108,7 +110,7 @@ Examples:
 			project = getDefaultProject()
 		}
 		if project == "" {
-			return fmt.Errorf("no project specified: set JIRA_PROJECT environment variable or use --project")
+			return fmt.Errorf("no project specified: set JIRA_PROJECT, configure a profile project, or use --project")
 		}
 
 		var text string
@@ -122,18 +124,16 @@ Examples:
 			fmt.Fprintf(os.Stderr, "JQL: %s\n", jql)
 		}
 
-		token, err := keychain.GetPAT(jiraURL)
+		client, err := newClient()
 		if err != nil {
 			return err
 		}
-
-		client := jira.NewClient(jiraURL, token, verbose)
-		result, err := client.Search(jql, lsMaxResults)
+		result, err := runSearch(cmd.Context(), client, jql, lsMaxResults, lsLimit, lsAll)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
 
-		f, err := formatter.New(outputFormat, jiraURL)
+		f, err := newFormatter()
 		if err != nil {
 			return err
 		}
@@ -148,6 +148,9 @@ func init() {
 	lsCmd.Flags().StringVar(&lsProject, "project", "", "Override default project (env: JIRA_PROJECT)")
 	lsCmd.Flags().BoolVar(&lsIncludeClosed, "include-closed", false, "Include closed/resolved issues")
 	lsCmd.Flags().IntVar(&lsMaxResults, "max-results", 50, "Maximum number of results to return")
+	lsCmd.Flags().BoolVar(&lsAll, "all", false, "Fetch all matching issues, following pagination")
+	lsCmd.Flags().IntVar(&lsLimit, "limit", 0, "Fetch up to this many issues, following pagination")
+	lsCmd.MarkFlagsMutuallyExclusive("all", "limit")
 	lsCmd.Flags().BoolVar(&lsSortCreated, "sort-created", false, "Sort by created date (newest first)")
 	lsCmd.Flags().BoolVar(&lsSortUpdated, "sort-updated", false, "Sort by updated date (newest first)")
 	lsCmd.MarkFlagsMutuallyExclusive("sort-created", "sort-updated")
@@ -1,16 +1,74 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/bentsolheim/jira-cli/internal/config"
+	"github.com/bentsolheim/jira-cli/internal/formatter"
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/bentsolheim/jira-cli/internal/keychain"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
-	outputFormat string
-	jiraURL      string
-	verbose      bool
+	outputFormat   string
+	jiraURL        string
+	verbose        bool
+	retries        int
+	retryWait      time.Duration
+	retryMaxWait   time.Duration
+	retryUnsafe    bool
+	timeout        time.Duration
+	requestTimeout time.Duration
+	authBackend    string
+	credHelper     string
+	profileName    string
+	epicChildren   string
+	noEpicChildren bool
+	markdownBodies bool
+	deployment     string
+	email          string
+	authMethod     string
+	username       string
+	oauthConsumer  string
+	oauthKeyFile   string
+	caFile         string
+	clientCert     string
+	clientKey      string
+	insecure       bool
+	proxyURL       string
+	noProxy        string
+)
+
+var (
+	// cfg is the loaded config file; empty if none exists.
+	cfg = &config.Config{}
+	// profile is the active profile; empty if none is configured.
+	profile = &config.Profile{}
+)
+
+// profileFlags are the profile keys that set the root flag of the same name.
+var profileFlags = []string{"url", "deployment", "email", "auth-method", "username", "oauth-consumer-key", "oauth-private-key", "output", "auth-backend", "credential-helper", "retries", "retry-wait", "retry-max-wait", "retry-unsafe", "timeout", "epic-children", "ca-file", "client-cert", "client-key", "insecure", "proxy", "no-proxy"}
+
+// Exit codes for commands that did not run to completion.
+const (
+	exitError       = 1
+	exitTimeout     = 124 // same as coreutils timeout(1)
+	exitInterrupted = 130 // 128 + SIGINT
+)
+
+// timeoutCtx carries the --timeout deadline set up in PersistentPreRunE, and
+// cancelTimeout releases it.
+var (
+	timeoutCtx    context.Context
+	cancelTimeout context.CancelFunc = func() {}
 )
 
 var rootCmd = &cobra.Command{
@@ -20,18 +78,208 @@ var rootCmd = &cobra.Command{
 and presents issues in structured formats (JSON, Markdown, text)
 suitable for AI/KI agent consumption.
 
-Authentication uses a Personal Access Token stored in the macOS Keychain.`,
+Authentication uses a Personal Access Token (Server/Data Center) or an API
+token with --email (Cloud), or basic auth, a session cookie or OAuth 1.0a
+(see 'jira auth login'). Secrets are stored in the macOS Keychain, the
+freedesktop Secret Service, pass, an encrypted file, or the JIRA_TOKEN
+environment variable (see --auth-backend).`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadProfile(); err != nil {
+			return err
+		}
+		// JIRA_EMAIL takes precedence over the profile, as other variables do.
+		if env := os.Getenv("JIRA_EMAIL"); env != "" && !cmd.Flags().Changed("email") {
+			if err := cmd.Flags().Set("email", env); err != nil {
+				return err
+			}
+		}
+		if err := applyProfile(cmd.Flags()); err != nil {
+			return err
+		}
+		keychain.SetFileDir(config.Dir())
+		if err := keychain.SetBackend(authBackend); err != nil {
+			return err
+		}
+		keychain.SetHelper(credHelper)
+		if timeout > 0 {
+			timeoutCtx, cancelTimeout = context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(timeoutCtx)
+		}
+		return nil
+	},
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// SIGINT/SIGTERM cancel the command context, aborting in-flight requests.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	timedOut := timeoutCtx != nil && timeoutCtx.Err() == context.DeadlineExceeded
+	cancelTimeout()
+	interrupted := ctx.Err() != nil
+	stop()
+
+	if err == nil {
+		return
+	}
+	switch {
+	case interrupted:
+		fmt.Fprintln(os.Stderr, "interrupted")
+		os.Exit(exitInterrupted)
+	case timedOut:
+		fmt.Fprintf(os.Stderr, "timed out after %s: %v\n", timeout, err)
+		os.Exit(exitTimeout)
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "request timed out after %s (--request-timeout): %v\n", requestTimeout, err)
+		os.Exit(exitError)
+	default:
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+}
+
+// loadProfile reads the config file and selects the active profile from
+// --profile, JIRA_PROFILE or the config's current-profile, in that order.
+func loadProfile() error {
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg = c
+
+	name := activeProfileName()
+	if name == "" {
+		return nil
+	}
+	p := cfg.Profile(name)
+	if p == nil {
+		return fmt.Errorf("profile %q not found in %s", name, config.Path())
+	}
+	profile = p
+	return nil
+}
+
+// activeProfileName returns the selected profile name, or "" if none is selected.
+func activeProfileName() string {
+	if profileName != "" {
+		return profileName
 	}
+	if name := os.Getenv("JIRA_PROFILE"); name != "" {
+		return name
+	}
+	return cfg.CurrentProfile
+}
+
+// applyProfile copies profile settings into root flags not given on the command line.
+func applyProfile(flags *pflag.FlagSet) error {
+	for _, key := range profileFlags {
+		value, err := profile.Get(key)
+		if err != nil {
+			return err
+		}
+		if value == "" || flags.Changed(key) {
+			continue
+		}
+		if err := flags.Set(key, value); err != nil {
+			return fmt.Errorf("profile setting %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// newClient creates a Jira client for jiraURL using the stored secret for
+// the selected auth method and the connection settings from the root flags.
+func newClient() (*jira.Client, error) {
+	cloud := deployment == jira.DeploymentCloud || (deployment == jira.DeploymentAuto && jira.IsCloudURL(jiraURL))
+	if cloud && authMethod == "" && email == "" {
+		return nil, fmt.Errorf("an account email is required for Jira Cloud API tokens: set --email, JIRA_EMAIL or 'jira config set email'")
+	}
+	secret, err := keychain.GetPAT(jiraURL)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := newAuthenticator(secret)
+	if err != nil {
+		return nil, err
+	}
+	return newClientWith(auth)
+}
+
+// newClientWith creates a Jira client for jiraURL that authenticates with
+// auth, or not at all if auth is nil, using the connection settings from the
+// root flags.
+func newClientWith(auth jira.Authenticator) (*jira.Client, error) {
+	if err := jira.ValidDeployment(deployment); err != nil {
+		return nil, err
+	}
+	if noEpicChildren {
+		epicChildren = jira.EpicChildrenNone
+	}
+	if err := jira.ValidEpicChildren(epicChildren); err != nil {
+		return nil, err
+	}
+	transport, err := jira.NewTransport(jira.TransportOptions{
+		CAFile:     caFile,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+		Insecure:   insecure,
+		Proxy:      proxyURL,
+		NoProxy:    noProxy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if insecure {
+		fmt.Fprintln(os.Stderr, "WARNING: TLS certificate verification is disabled (insecure). The connection to Jira, including your credentials, can be intercepted.")
+	}
+
+	policy := jira.RetryPolicy{
+		MaxAttempts:        retries + 1,
+		BaseDelay:          retryWait,
+		MaxDelay:           retryMaxWait,
+		RetryNonIdempotent: retryUnsafe,
+	}
+	return jira.NewClient(jiraURL, auth, verbose,
+		jira.WithRetryPolicy(policy),
+		jira.WithRequestTimeout(requestTimeout),
+		jira.WithCacheDir(config.CacheDir()),
+		jira.WithFieldOverrides(profile.Fields),
+		jira.WithEpicChildren(epicChildren),
+		jira.WithDeployment(deployment),
+		jira.WithTransport(transport),
+	), nil
+}
+
+// newFormatter creates the formatter for the output format selected by -o.
+func newFormatter() (formatter.Formatter, error) {
+	return formatter.New(outputFormat, jiraURL, formatter.WithMarkdownBodies(markdownBodies))
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Config profile to use (env: JIRA_PROFILE)")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "markdown", "Output format: markdown, json, text")
+	rootCmd.PersistentFlags().BoolVar(&markdownBodies, "markdown-bodies", false, "Convert descriptions and comments from Jira wiki markup to Markdown in JSON output")
 	rootCmd.PersistentFlags().StringVar(&jiraURL, "url", "https://jira.sits.no", "Jira base URL")
+	rootCmd.PersistentFlags().StringVar(&deployment, "deployment", jira.DeploymentAuto, "Jira deployment: auto (detect), server (Server/Data Center) or cloud")
+	rootCmd.PersistentFlags().StringVar(&email, "email", "", "Account email for Jira Cloud API token auth (env: JIRA_EMAIL)")
+	rootCmd.PersistentFlags().StringVar(&authMethod, "auth-method", "", "Authentication: bearer (PAT), basic, cookie or oauth (default: basic with --email, else bearer)")
+	rootCmd.PersistentFlags().StringVar(&username, "username", "", "Username for basic and cookie auth")
+	rootCmd.PersistentFlags().StringVar(&oauthConsumer, "oauth-consumer-key", "", "Consumer key of the OAuth 1.0a application link")
+	rootCmd.PersistentFlags().StringVar(&oauthKeyFile, "oauth-private-key", "", "PEM file with the RSA private key of the OAuth 1.0a application link")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show raw HTTP responses from Jira")
+	rootCmd.PersistentFlags().IntVar(&retries, "retries", jira.DefaultRetryPolicy.MaxAttempts-1, "Retries for throttled (429) or unavailable (502/503/504) responses")
+	rootCmd.PersistentFlags().DurationVar(&retryWait, "retry-wait", jira.DefaultRetryPolicy.BaseDelay, "Initial backoff between retries, doubled per attempt")
+	rootCmd.PersistentFlags().DurationVar(&retryMaxWait, "retry-max-wait", jira.DefaultRetryPolicy.MaxDelay, "Upper bound for backoff and Retry-After waits")
+	rootCmd.PersistentFlags().BoolVar(&retryUnsafe, "retry-unsafe", false, "Also retry non-idempotent requests (POST), which may create duplicates")
+	rootCmd.PersistentFlags().StringVar(&authBackend, "auth-backend", keychain.BackendAuto, "Credential store: auto, keychain, secret-service, pass, file, env")
+	rootCmd.PersistentFlags().StringVar(&credHelper, "credential-helper", "", "External credential helper (git credential protocol); overrides --auth-backend")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort the whole command after this duration, e.g. 2m (0 = no limit)")
+	rootCmd.PersistentFlags().StringVar(&epicChildren, "epic-children", jira.EpicChildrenEpicLink, "How to find the issues in an epic: epic-link, parent, none, or a JQL template with {key}")
+	rootCmd.PersistentFlags().BoolVar(&noEpicChildren, "no-epic-children", false, "Do not fetch the issues in an epic (same as --epic-children none)")
+	rootCmd.PersistentFlags().StringVar(&caFile, "ca-file", "", "PEM bundle of extra trusted certificate authorities")
+	rootCmd.PersistentFlags().StringVar(&clientCert, "client-cert", "", "PEM client certificate for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&clientKey, "client-key", "", "PEM key for --client-cert, if not in the same file")
+	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "Skip TLS certificate verification (unsafe; for testing only)")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "Proxy URL, overriding HTTP_PROXY and HTTPS_PROXY")
+	rootCmd.PersistentFlags().StringVar(&noProxy, "no-proxy", "", "Comma-separated hosts to reach without the proxy, in addition to NO_PROXY")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 30*time.Second, "Timeout for a single HTTP request (0 = no limit)")
 }
@@ -1,17 +1,31 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
-	"github.com/bentsolheim/jira-cli/internal/formatter"
 	"github.com/bentsolheim/jira-cli/internal/jira"
-	"github.com/bentsolheim/jira-cli/internal/keychain"
 	"github.com/spf13/cobra"
 )
 
-var maxResults int
+var (
+	maxResults   int
+	searchAll    bool
+	searchLimit  int
+	searchFields string
+)
+
+// runSearch executes jql either as a single page of maxResults issues, or —
+// when all or limit is set — by paging through results until exhausted or
+// until limit issues have been collected.
+func runSearch(ctx context.Context, client *jira.Client, jql string, maxResults, limit int, all bool) (*jira.SearchResult, error) {
+	if all || limit > 0 {
+		return client.SearchAll(ctx, jql, limit)
+	}
+	return client.Search(ctx, jql, maxResults)
+}
 
 var searchCmd = &cobra.Command{
 	Use:   "search [JQL]",
@@ -21,23 +35,32 @@ var searchCmd = &cobra.Command{
 Examples:
   jira search "project = MYPROJ AND status = Open"
   jira search "assignee = currentUser() ORDER BY updated DESC"
-  jira search "labels = backend AND sprint in openSprints()" --max-results 20 -o markdown`,
+  jira search "labels = backend AND sprint in openSprints()" --max-results 20 -o markdown
+  jira search "project = MYPROJ" --all -o json
+  jira search "project = MYPROJ ORDER BY created DESC" --limit 500
+  jira search "project = MYPROJ" --fields "Story Points,Sprint"`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		jql := strings.Join(args, " ")
 
-		token, err := keychain.GetPAT(jiraURL)
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		fields, err := lookupFields(cmd.Context(), client, searchFields)
 		if err != nil {
 			return err
 		}
 
-		client := jira.NewClient(jiraURL, token, verbose)
-		result, err := client.Search(jql, maxResults)
+		result, err := runSearch(cmd.Context(), client, jql, maxResults, searchLimit, searchAll)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
+		for i := range result.Issues {
+			jira.SetExtraFields(&result.Issues[i], fields)
+		}
 
-		f, err := formatter.New(outputFormat, jiraURL)
+		f, err := newFormatter()
 		if err != nil {
 			return err
 		}
@@ -48,5 +71,9 @@ Examples:
 
 func init() {
 	searchCmd.Flags().IntVar(&maxResults, "max-results", 50, "Maximum number of results to return")
+	searchCmd.Flags().BoolVar(&searchAll, "all", false, "Fetch all matching issues, following pagination")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 0, "Fetch up to this many issues, following pagination")
+	searchCmd.Flags().StringVar(&searchFields, "fields", "", "Comma-separated extra fields (names or IDs) to include in the output")
+	searchCmd.MarkFlagsMutuallyExclusive("all", "limit")
 	rootCmd.AddCommand(searchCmd)
 }
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/bentsolheim/jira-cli/internal/timesheet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	timesheetFrom string
+	timesheetTo   string
+	timesheetUser string
+)
+
+// weekRange returns Monday through Sunday of the week containing t.
+func weekRange(t time.Time) (time.Time, time.Time) {
+	offset := (int(t.Weekday()) + 6) % 7
+	monday := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	return monday, monday.AddDate(0, 0, 6)
+}
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Report logged hours per issue and day",
+	Long: `Report the hours a user logged in a date range, with one row per issue
+and one column per day. Defaults to the current week (Monday–Sunday) and
+the authenticated user.
+
+Output formats: markdown (default), text, csv, json.
+
+Examples:
+  jira timesheet
+  jira timesheet --from 2026-03-02 --to 2026-03-06 -o csv > week10.csv
+  jira timesheet --user jdoe -o text`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		from, to := weekRange(time.Now())
+		var err error
+		if timesheetFrom != "" {
+			if from, err = time.ParseInLocation("2006-01-02", timesheetFrom, time.Local); err != nil {
+				return fmt.Errorf("invalid --from %q (use YYYY-MM-DD)", timesheetFrom)
+			}
+		}
+		if timesheetTo != "" {
+			if to, err = time.ParseInLocation("2006-01-02", timesheetTo, time.Local); err != nil {
+				return fmt.Errorf("invalid --to %q (use YYYY-MM-DD)", timesheetTo)
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to is before --from")
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		var user *jira.User
+		if timesheetUser == "" {
+			user, err = client.Myself(ctx)
+		} else {
+			user, err = client.FindUser(ctx, timesheetUser)
+		}
+		if err != nil {
+			return fmt.Errorf("user: %w", err)
+		}
+
+		jql := fmt.Sprintf(`worklogAuthor = "%s" AND worklogDate >= "%s" AND worklogDate <= "%s" ORDER BY key ASC`,
+			user.ID(), from.Format("2006-01-02"), to.Format("2006-01-02"))
+		result, err := client.SearchAll(ctx, jql, 0)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+
+		sheet := timesheet.New(user.ID(), from, to)
+		for i := range result.Issues {
+			issue := &result.Issues[i]
+			worklogs, err := client.GetWorklogs(ctx, issue.Key)
+			if err != nil {
+				return fmt.Errorf("fetching worklogs for %s: %w", issue.Key, err)
+			}
+			sheet.Add(issue, worklogs.Worklogs)
+		}
+
+		return timesheet.Write(os.Stdout, sheet, outputFormat)
+	},
+}
+
+func init() {
+	timesheetCmd.Flags().StringVar(&timesheetFrom, "from", "", "First day (YYYY-MM-DD, default Monday this week)")
+	timesheetCmd.Flags().StringVar(&timesheetTo, "to", "", "Last day (YYYY-MM-DD, default Sunday this week)")
+	timesheetCmd.Flags().StringVar(&timesheetUser, "user", "", "Username or email (default the authenticated user)")
+	rootCmd.AddCommand(timesheetCmd)
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	transitionResolution string
+	transitionComment    string
+	transitionFile       string
+)
+
+// splitTransitionArgs separates the leading issue keys from the target status
+// or transition name, which may be given unquoted as several words.
+func splitTransitionArgs(args []string) (keys []string, target string) {
+	var rest []string
+	for _, arg := range args {
+		parts := splitKeys([]string{arg})
+		isKeys := len(rest) == 0 && len(parts) > 0
+		for _, p := range parts {
+			if !issueKeyPattern.MatchString(p) {
+				isKeys = false
+			}
+		}
+		if isKeys {
+			keys = append(keys, parts...)
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	return keys, strings.Join(rest, " ")
+}
+
+// readTransitionInput reads the YAML from --file ("-" for stdin), then applies
+// the --resolution and --comment flags on top.
+func readTransitionInput() (*jira.TransitionInput, error) {
+	input := &jira.TransitionInput{}
+	if transitionFile != "" {
+		var data []byte
+		var err error
+		if transitionFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(transitionFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading transition input: %w", err)
+		}
+		if err := yaml.Unmarshal(data, input); err != nil {
+			return nil, fmt.Errorf("parsing YAML: %w", err)
+		}
+	}
+	if transitionResolution != "" {
+		input.Resolution = transitionResolution
+	}
+	if transitionComment != "" {
+		input.Comment = transitionComment
+	}
+	return input, nil
+}
+
+var transitionCmd = &cobra.Command{
+	Use:   "transition KEY... [STATUS]",
+	Short: "Move issues through their workflow",
+	Long: `Move one or more issues to a new status.
+
+STATUS is matched case-insensitively against the target status of each
+available transition, then against the transition names, so localized
+names such as "I gang" work. Without STATUS, the available transitions
+are listed.
+
+Screen fields can be given as YAML with --file (use "-" for stdin):
+  resolution: Fixed
+  comment: Deployed to production
+  fields:
+    Fix Version/s: [2.1]
+
+Examples:
+  jira transition MUP-123                        # List available transitions
+  jira transition MUP-123 "I gang"
+  jira transition MUP-123 MUP-124 Done --resolution Fixed
+  jira transition MUP-123,MUP-124 Lukket --comment "Duplicate of MUP-100"
+  echo 'resolution: Fixed' | jira transition MUP-123 Done -f -`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys, target := splitTransitionArgs(args)
+		if len(keys) == 0 {
+			return fmt.Errorf("at least one issue key is required")
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+
+		if target == "" {
+			for i, key := range keys {
+				transitions, err := client.GetTransitions(cmd.Context(), key)
+				if err != nil {
+					return fmt.Errorf("failed to get transitions for %s: %w", key, err)
+				}
+				if i > 0 {
+					fmt.Fprint(os.Stdout, "\n---\n\n")
+				}
+				if err := f.FormatTransitions(os.Stdout, key, transitions); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		input, err := readTransitionInput()
+		if err != nil {
+			return err
+		}
+		fields, err := client.ConvertFieldValues(cmd.Context(), input.Fields)
+		if err != nil {
+			return err
+		}
+		if input.Resolution != "" {
+			fields["resolution"] = map[string]interface{}{"name": input.Resolution}
+		}
+
+		failed, printed := 0, 0
+		for _, key := range keys {
+			issue, err := transitionIssue(cmd, client, key, target, fields, input.Comment)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", key, err)
+				failed++
+				continue
+			}
+			if printed > 0 {
+				fmt.Fprint(os.Stdout, "\n---\n\n")
+			}
+			printed++
+			if err := f.FormatIssue(os.Stdout, issue); err != nil {
+				return err
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d transitions failed", failed, len(keys))
+		}
+		return nil
+	},
+}
+
+// transitionIssue moves a single issue to target and returns the updated issue.
+func transitionIssue(cmd *cobra.Command, client *jira.Client, key, target string, fields map[string]interface{}, comment string) (*jira.Issue, error) {
+	transitions, err := client.GetTransitions(cmd.Context(), key)
+	if err != nil {
+		return nil, fmt.Errorf("getting transitions: %w", err)
+	}
+	t, err := jira.FindTransition(transitions, target)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &jira.TransitionRequest{
+		Transition: jira.TransitionRef{ID: t.ID},
+		Fields:     fields,
+	}
+	if comment != "" {
+		req.Update = map[string][]map[string]interface{}{
+			"comment": {{"add": map[string]interface{}{"body": comment}}},
+		}
+	}
+	if err := client.DoTransition(cmd.Context(), key, req); err != nil {
+		return nil, fmt.Errorf("transition %q: %w", t.Name, err)
+	}
+
+	return client.GetIssue(cmd.Context(), key)
+}
+
+func init() {
+	transitionCmd.Flags().StringVar(&transitionResolution, "resolution", "", "Resolution to set, e.g. Fixed")
+	transitionCmd.Flags().StringVar(&transitionComment, "comment", "", "Comment to add with the transition")
+	transitionCmd.Flags().StringVarP(&transitionFile, "file", "f", "", "YAML file with resolution, comment and screen fields (- for stdin)")
+	rootCmd.AddCommand(transitionCmd)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/bentsolheim/jira-cli/internal/tree"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree KEY",
+	Short: "Show the issue hierarchy around an issue",
+	Long: `Show the Parent Link → Epic → Story → Sub-task hierarchy around an issue.
+The issue's ancestors are shown up to the top of the hierarchy, and every
+issue below it is fetched recursively. Each issue with children shows how
+many of its descendants are to do, in progress and done. The issues in an
+epic are found as set with --epic-children.
+
+Output formats (-o): markdown (default; a nested list), text, json.
+
+Examples:
+  jira tree MUP-10
+  jira tree MUP-123 -o text`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		root, err := tree.Build(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		return tree.Write(os.Stdout, root, outputFormat)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(treeCmd)
+}
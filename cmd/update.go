@@ -1,18 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 
-	"github.com/bentsolheim/jira-cli/internal/formatter"
 	"github.com/bentsolheim/jira-cli/internal/jira"
-	"github.com/bentsolheim/jira-cli/internal/keychain"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
-var updateIssueKey string
+var (
+	updateIssueKey string
+	updateMarkdown bool
+)
 
 var updateCmd = &cobra.Command{
 	Use:   "update",
@@ -23,18 +25,37 @@ Requires --issue-key flag to specify which issue to update.
 Supported fields (all optional):
   summary:     Issue summary
   description: Issue description
+  descriptionFormat: wiki (default) or markdown for the rich text fields, same as --markdown
   type:        Issue type name
   labels:      List of labels
   epicLink:    Epic issue key to link to
   epicName:    Epic short name
   parent:      Parent issue key (for subtasks)
   parentLink:  Parent Link for Epic → Del-leveranse hierarchy
+  storyPoints: Story point estimate
+  assignee:    Username, email address, or "me"
+  priority:    Priority name
+  components:  List of component names (replaces existing)
+  fixVersions: List of fix version names (replaces existing)
+  affectsVersions: List of affected version names (replaces existing)
+  dueDate:     Due date (YYYY-MM-DD)
+  environment: Environment description
+  originalEstimate: Original estimate (e.g., "2d 4h")
+  fields:      Map of any other field, by name or ID, to its value
+
+Keys that are left out are not changed. A null or empty value clears the
+field. List fields replace their value wholesale unless given as a map of
+add, remove and set operations.
 
 Example YAML:
   summary: Updated summary
+  description: null
+  epicLink: null
   labels:
-    - priority
-    - backend
+    add: [priority, backend]
+    remove: [triage]
+  fields:
+    Team: {set: Platform}
 
 Usage:
   echo 'summary: Updated task name
@@ -50,13 +71,22 @@ Usage:
 			return fmt.Errorf("reading stdin: %w", err)
 		}
 
+		values, changes, err := splitUpdateInput(yamlData)
+		if err != nil {
+			return fmt.Errorf("parsing YAML: %w", err)
+		}
 		var input jira.IssueInput
-		if err := yaml.Unmarshal(yamlData, &input); err != nil {
+		if err := values.Decode(&input); err != nil {
 			return fmt.Errorf("parsing YAML: %w", err)
 		}
 
+		markdown, err := richTextMarkdown(&input, updateMarkdown)
+		if err != nil {
+			return err
+		}
+
 		req := &jira.IssueUpdateRequest{
-			Fields: jira.IssueUpdateFields{},
+			Fields: jira.IssueUpdateFields{Markdown: markdown},
 		}
 
 		if input.Summary != "" {
@@ -83,19 +113,62 @@ Usage:
 		if input.ParentLink != "" {
 			req.Fields.ParentLink = &input.ParentLink
 		}
+		if input.StoryPoints != nil {
+			req.Fields.StoryPoints = input.StoryPoints
+		}
+		if input.Priority != "" {
+			req.Fields.Priority = &jira.NameRef{Name: input.Priority}
+		}
+		if len(input.Components) > 0 {
+			refs := jira.NameRefs(input.Components)
+			req.Fields.Components = &refs
+		}
+		if len(input.FixVersions) > 0 {
+			refs := jira.NameRefs(input.FixVersions)
+			req.Fields.FixVersions = &refs
+		}
+		if len(input.AffectsVersions) > 0 {
+			refs := jira.NameRefs(input.AffectsVersions)
+			req.Fields.Versions = &refs
+		}
+		if input.DueDate != "" {
+			req.Fields.DueDate = &input.DueDate
+		}
+		if input.Environment != "" {
+			req.Fields.Environment = &input.Environment
+		}
+		if input.OriginalEstimate != "" {
+			req.Fields.TimeTracking = &jira.TimeTracking{OriginalEstimate: input.OriginalEstimate}
+		}
 
-		token, err := keychain.GetPAT(jiraURL)
+		client, err := newClient()
 		if err != nil {
 			return err
 		}
 
-		client := jira.NewClient(jiraURL, token, verbose)
-		issue, err := client.UpdateIssue(updateIssueKey, req)
+		if input.Assignee != "" {
+			if req.Fields.Assignee, err = resolveAssignee(cmd.Context(), client, input.Assignee); err != nil {
+				return err
+			}
+		}
+
+		if len(input.Fields) > 0 {
+			custom, err := client.ConvertFieldValues(cmd.Context(), input.Fields)
+			if err != nil {
+				return err
+			}
+			req.Fields.Custom = custom
+		}
+		if err := applyFieldChanges(cmd.Context(), client, req, changes); err != nil {
+			return err
+		}
+
+		issue, err := client.UpdateIssue(cmd.Context(), updateIssueKey, req)
 		if err != nil {
 			return fmt.Errorf("updating issue: %w", err)
 		}
 
-		f, err := formatter.New(outputFormat, jiraURL)
+		f, err := newFormatter()
 		if err != nil {
 			return err
 		}
@@ -104,8 +177,207 @@ Usage:
 	},
 }
 
+// updateOps are the operations accepted in place of a value, e.g.
+// "labels: {add: [urgent]}".
+var updateOps = map[string]bool{"add": true, "remove": true, "set": true}
+
+// updateTarget is the Jira field behind a top-level update YAML key. Keys
+// backed by instance-specific custom fields name a FieldMap entry instead.
+type updateTarget struct {
+	id       string
+	fieldKey string
+	schema   jira.FieldSchema
+}
+
+var updateTargets = map[string]updateTarget{
+	"summary":         {id: "summary", schema: jira.FieldSchema{Type: "string"}},
+	"description":     {id: "description", schema: jira.FieldSchema{Type: "string"}},
+	"type":            {id: "issuetype", schema: jira.FieldSchema{Type: "issuetype"}},
+	"labels":          {id: "labels", schema: jira.FieldSchema{Type: "array", Items: "string"}},
+	"parent":          {id: "parent", schema: jira.FieldSchema{Type: "issuelink"}},
+	"assignee":        {id: "assignee", schema: jira.FieldSchema{Type: "user"}},
+	"priority":        {id: "priority", schema: jira.FieldSchema{Type: "priority"}},
+	"components":      {id: "components", schema: jira.FieldSchema{Type: "array", Items: "component"}},
+	"fixVersions":     {id: "fixVersions", schema: jira.FieldSchema{Type: "array", Items: "version"}},
+	"affectsVersions": {id: "versions", schema: jira.FieldSchema{Type: "array", Items: "version"}},
+	"dueDate":         {id: "duedate", schema: jira.FieldSchema{Type: "date"}},
+	"environment":     {id: "environment", schema: jira.FieldSchema{Type: "string"}},
+	"epicLink":        {fieldKey: jira.FieldEpicLink},
+	"epicName":        {fieldKey: jira.FieldEpicName},
+	"parentLink":      {fieldKey: jira.FieldParentLink},
+	"storyPoints":     {fieldKey: jira.FieldStoryPoints},
+}
+
+// fieldChange is an empty value or an operation map taken out of the update
+// YAML. ops is nil when the field is to be cleared.
+type fieldChange struct {
+	key    string // top-level YAML key, or field name/ID under "fields"
+	custom bool   // whether key came from the "fields" map
+	ops    *yaml.Node
+}
+
+// splitUpdateInput separates empty values and add/remove/set operations from
+// plain values, which are returned as a mapping node to decode into an
+// IssueInput. Keys that are absent are left alone.
+func splitUpdateInput(data []byte) (*yaml.Node, []fieldChange, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("expected a map of fields")
+	}
+
+	var changes []fieldChange
+	values := splitChanges(root, false, &changes)
+	for i := 0; i < len(values.Content); i += 2 {
+		if values.Content[i].Value == "fields" && values.Content[i+1].Kind == yaml.MappingNode {
+			values.Content[i+1] = splitChanges(values.Content[i+1], true, &changes)
+		}
+	}
+	return values, changes, nil
+}
+
+// splitChanges appends the empty and operation entries of m to changes and
+// returns a copy of m without them.
+func splitChanges(m *yaml.Node, custom bool, changes *[]fieldChange) *yaml.Node {
+	values := &yaml.Node{Kind: yaml.MappingNode, Tag: m.Tag}
+	for i := 0; i < len(m.Content); i += 2 {
+		key, value := m.Content[i], m.Content[i+1]
+		switch {
+		case isEmptyNode(value):
+			*changes = append(*changes, fieldChange{key: key.Value, custom: custom})
+		case isOpsNode(value):
+			*changes = append(*changes, fieldChange{key: key.Value, custom: custom, ops: value})
+		default:
+			values.Content = append(values.Content, key, value)
+		}
+	}
+	return values
+}
+
+// isEmptyNode reports whether n is null, an empty string or an empty list,
+// all of which clear the field.
+func isEmptyNode(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		return n.Tag == "!!null" || (n.Tag == "!!str" && n.Value == "")
+	case yaml.SequenceNode:
+		return len(n.Content) == 0
+	}
+	return false
+}
+
+// isOpsNode reports whether n is a non-empty map whose keys are all operations.
+func isOpsNode(n *yaml.Node) bool {
+	if n.Kind != yaml.MappingNode || len(n.Content) == 0 {
+		return false
+	}
+	for i := 0; i < len(n.Content); i += 2 {
+		if !updateOps[n.Content[i].Value] {
+			return false
+		}
+	}
+	return true
+}
+
+// applyFieldChanges adds the clears and operations in changes to req.
+func applyFieldChanges(ctx context.Context, client *jira.Client, req *jira.IssueUpdateRequest, changes []fieldChange) error {
+	for _, ch := range changes {
+		id, schema, err := changeTarget(ctx, client, ch)
+		if err != nil {
+			return err
+		}
+
+		if ch.ops == nil {
+			if req.Fields.Custom == nil {
+				req.Fields.Custom = map[string]interface{}{}
+			}
+			if schema.Type == "array" {
+				req.Fields.Custom[id] = []interface{}{}
+			} else {
+				req.Fields.Custom[id] = nil
+			}
+			continue
+		}
+
+		for i := 0; i < len(ch.ops.Content); i += 2 {
+			op := ch.ops.Content[i].Value
+			var value interface{}
+			if err := ch.ops.Content[i+1].Decode(&value); err != nil {
+				return fmt.Errorf("%s.%s: %w", ch.key, op, err)
+			}
+			if op == "set" {
+				v, err := client.ConvertFieldValue(ctx, schema, value)
+				if err != nil {
+					return fmt.Errorf("%s: %w", ch.key, err)
+				}
+				addOperation(req, id, op, v)
+				continue
+			}
+
+			items, ok := value.([]interface{})
+			if !ok {
+				items = []interface{}{value}
+			}
+			for _, item := range items {
+				v, err := client.ConvertFieldItem(ctx, schema, item)
+				if err != nil {
+					return fmt.Errorf("%s: cannot %s: %w", ch.key, op, err)
+				}
+				addOperation(req, id, op, v)
+			}
+		}
+	}
+	return nil
+}
+
+// changeTarget resolves the field ID and schema a change applies to.
+func changeTarget(ctx context.Context, client *jira.Client, ch fieldChange) (string, jira.FieldSchema, error) {
+	if ch.custom {
+		f, err := client.FindField(ctx, ch.key)
+		if err != nil {
+			return "", jira.FieldSchema{}, err
+		}
+		return f.ID, f.Schema, nil
+	}
+
+	target, ok := updateTargets[ch.key]
+	if !ok {
+		return "", jira.FieldSchema{}, fmt.Errorf("%s cannot be cleared or changed with add/remove/set", ch.key)
+	}
+	if target.fieldKey == "" {
+		return target.id, target.schema, nil
+	}
+	fm, err := client.FieldMap(ctx)
+	if err != nil {
+		return "", jira.FieldSchema{}, err
+	}
+	id, err := fm.ID(target.fieldKey)
+	if err != nil {
+		return "", jira.FieldSchema{}, err
+	}
+	f, err := client.FindField(ctx, id)
+	if err != nil {
+		return "", jira.FieldSchema{}, err
+	}
+	return f.ID, f.Schema, nil
+}
+
+func addOperation(req *jira.IssueUpdateRequest, id, op string, value interface{}) {
+	if req.Update == nil {
+		req.Update = map[string][]map[string]interface{}{}
+	}
+	req.Update[id] = append(req.Update[id], map[string]interface{}{op: value})
+}
+
 func init() {
 	updateCmd.Flags().StringVar(&updateIssueKey, "issue-key", "", "Issue key to update (required)")
 	updateCmd.MarkFlagRequired("issue-key")
+	updateCmd.Flags().BoolVar(&updateMarkdown, "markdown", false, "Rich text (description, environment, text area fields) is Markdown rather than Jira wiki markup")
 	rootCmd.AddCommand(updateCmd)
 }
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	worklogComment string
+	worklogStarted string
+)
+
+var worklogCmd = &cobra.Command{
+	Use:   "worklog",
+	Short: "Log, list and delete time spent on issues",
+}
+
+// startedLayouts are the accepted --started formats, tried in order.
+var startedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"15:04",
+}
+
+// parseStarted parses a --started value in local time. A time alone is
+// taken as today; a date alone starts at 09:00.
+func parseStarted(s string, now time.Time) (time.Time, error) {
+	for _, layout := range startedLayouts {
+		t, err := time.ParseInLocation(layout, s, now.Location())
+		if err != nil {
+			continue
+		}
+		switch layout {
+		case "15:04":
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+		case "2006-01-02":
+			return t.Add(9 * time.Hour), nil
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --started %q (use YYYY-MM-DD, \"YYYY-MM-DD HH:MM\", HH:MM or RFC 3339)", s)
+}
+
+var worklogAddCmd = &cobra.Command{
+	Use:   "add KEY DURATION",
+	Short: "Log time on an issue",
+	Long: `Log time on an issue. DURATION uses Jira's format: w(eeks), d(ays),
+h(ours) and m(inutes), e.g. 1h30m, "2d 4h" or 1.5h. A bare number is
+minutes. Days are 8 hours and weeks 5 days.
+
+Examples:
+  jira worklog add MUP-123 1h30m --comment "Code review"
+  jira worklog add MUP-123 "1d 2h" --started 2026-03-02
+  jira worklog add MUP-123 45m --started "2026-03-02 13:00"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		seconds, err := jira.ParseDuration(strings.Join(args[1:], " "))
+		if err != nil {
+			return err
+		}
+
+		started := time.Now()
+		if worklogStarted != "" {
+			if started, err = parseStarted(worklogStarted, started); err != nil {
+				return err
+			}
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		worklog, err := client.AddWorklog(cmd.Context(), key, &jira.WorklogRequest{
+			Comment:          worklogComment,
+			Started:          jira.FormatTime(started),
+			TimeSpentSeconds: seconds,
+		})
+		if err != nil {
+			return fmt.Errorf("adding worklog: %w", err)
+		}
+
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+		return f.FormatWorklogs(os.Stdout, key, &jira.Worklogs{
+			Worklogs: []jira.Worklog{*worklog},
+			Total:    1,
+		})
+	},
+}
+
+var worklogListCmd = &cobra.Command{
+	Use:   "list KEY",
+	Short: "List the time logged on an issue",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		worklogs, err := client.GetWorklogs(cmd.Context(), key)
+		if err != nil {
+			return fmt.Errorf("listing worklogs: %w", err)
+		}
+
+		f, err := newFormatter()
+		if err != nil {
+			return err
+		}
+		return f.FormatWorklogs(os.Stdout, key, worklogs)
+	},
+}
+
+var worklogDeleteCmd = &cobra.Command{
+	Use:   "delete KEY ID",
+	Short: "Delete a worklog",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteWorklog(cmd.Context(), args[0], args[1]); err != nil {
+			return fmt.Errorf("deleting worklog: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Worklog %s deleted from %s.\n", args[1], args[0])
+		return nil
+	},
+}
+
+func init() {
+	worklogAddCmd.Flags().StringVar(&worklogComment, "comment", "", "Work description")
+	worklogAddCmd.Flags().StringVar(&worklogStarted, "started", "", "When the work started (default now)")
+
+	worklogCmd.AddCommand(worklogAddCmd)
+	worklogCmd.AddCommand(worklogListCmd)
+	worklogCmd.AddCommand(worklogDeleteCmd)
+	rootCmd.AddCommand(worklogCmd)
+}
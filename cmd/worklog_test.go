@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStarted(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	now := time.Date(2026, 3, 4, 15, 30, 45, 0, cet)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2026-03-02", time.Date(2026, 3, 2, 9, 0, 0, 0, cet), false},
+		{"2026-03-02 13:00", time.Date(2026, 3, 2, 13, 0, 0, 0, cet), false},
+		{"2026-03-02T13:00", time.Date(2026, 3, 2, 13, 0, 0, 0, cet), false},
+		{"13:00", time.Date(2026, 3, 4, 13, 0, 0, 0, cet), false},
+		{"2026-03-02T13:00:00Z", time.Date(2026, 3, 2, 13, 0, 0, 0, time.UTC), false},
+		{"2026-03-02T13:00:00+05:30", time.Date(2026, 3, 2, 7, 30, 0, 0, time.UTC), false},
+		{"", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+		{"25:00", time.Time{}, true},
+		{"2026-13-01", time.Time{}, true},
+		{"02.03.2026", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseStarted(tt.in, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseStarted(%q) = %s, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("parseStarted(%q) = %s, %v; want %s", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
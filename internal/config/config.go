@@ -0,0 +1,415 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bentsolheim/jira-cli/internal/formatter"
+	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/bentsolheim/jira-cli/internal/keychain"
+	"gopkg.in/yaml.v3"
+)
+
+const appName = "jira-cli"
+
+// Config is the contents of config.yaml: a set of named profiles, one per
+// Jira instance or project, and the profile used when none is selected.
+type Config struct {
+	CurrentProfile string              `yaml:"current-profile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile holds the settings for one Jira instance. Empty values fall back
+// to flags, environment variables and built-in defaults.
+type Profile struct {
+	URL              string   `yaml:"url,omitempty"`
+	Deployment       string   `yaml:"deployment,omitempty"`
+	Email            string   `yaml:"email,omitempty"`
+	AuthMethod       string   `yaml:"auth-method,omitempty"`
+	Username         string   `yaml:"username,omitempty"`
+	OAuthConsumerKey string   `yaml:"oauth-consumer-key,omitempty"`
+	OAuthPrivateKey  string   `yaml:"oauth-private-key,omitempty"`
+	Project          string   `yaml:"project,omitempty"`
+	ClosedStatuses   []string `yaml:"closed-statuses,omitempty"`
+	Output           string   `yaml:"output,omitempty"`
+	AuthBackend      string   `yaml:"auth-backend,omitempty"`
+	CredentialHelper string   `yaml:"credential-helper,omitempty"`
+	Retries          *int     `yaml:"retries,omitempty"`
+	RetryWait        string   `yaml:"retry-wait,omitempty"`
+	RetryMaxWait     string   `yaml:"retry-max-wait,omitempty"`
+	RetryUnsafe      bool     `yaml:"retry-unsafe,omitempty"`
+	Timeout          string   `yaml:"timeout,omitempty"`
+	EpicChildren     string   `yaml:"epic-children,omitempty"`
+	CAFile           string   `yaml:"ca-file,omitempty"`
+	ClientCert       string   `yaml:"client-cert,omitempty"`
+	ClientKey        string   `yaml:"client-key,omitempty"`
+	Insecure         bool     `yaml:"insecure,omitempty"`
+	Proxy            string   `yaml:"proxy,omitempty"`
+	NoProxy          string   `yaml:"no-proxy,omitempty"`
+
+	// Fields pins logical custom fields (epic-link, epic-name, parent-link,
+	// story-points, sprint) to field IDs, overriding discovery by name.
+	Fields map[string]string `yaml:"fields,omitempty"`
+}
+
+// fieldKeyPrefix prefixes keys addressing Profile.Fields, e.g. fields.epic-link.
+const fieldKeyPrefix = "fields."
+
+// Keys lists the profile keys accepted by Get and Set, in display order.
+var Keys = []string{
+	"url",
+	"deployment",
+	"email",
+	"auth-method",
+	"username",
+	"oauth-consumer-key",
+	"oauth-private-key",
+	"project",
+	"closed-statuses",
+	"output",
+	"auth-backend",
+	"credential-helper",
+	"retries",
+	"retry-wait",
+	"retry-max-wait",
+	"retry-unsafe",
+	"timeout",
+	"epic-children",
+	"ca-file",
+	"client-cert",
+	"client-key",
+	"insecure",
+	"proxy",
+	"no-proxy",
+	"fields.<name>",
+}
+
+// Dir returns $XDG_CONFIG_HOME/jira-cli, defaulting to ~/.config/jira-cli.
+func Dir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return appName
+	}
+	return filepath.Join(home, ".config", appName)
+}
+
+// CacheDir returns $XDG_CACHE_HOME/jira-cli, defaulting to ~/.cache/jira-cli.
+func CacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, appName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cache", appName)
+}
+
+// Path returns the location of config.yaml.
+func Path() string {
+	return filepath.Join(Dir(), "config.yaml")
+}
+
+// Load reads config.yaml. A missing file yields an empty Config.
+func Load() (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(Path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", Path(), err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config back to config.yaml.
+func (c *Config) Save() error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := os.MkdirAll(Dir(), 0o700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(Path(), buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// ProfileNames returns the configured profile names in sorted order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the named profile, or nil if it does not exist.
+func (c *Config) Profile(name string) *Profile {
+	return c.Profiles[name]
+}
+
+// EnsureProfile returns the named profile, creating it if necessary.
+func (c *Config) EnsureProfile(name string) *Profile {
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Profile{}
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		p = &Profile{}
+		c.Profiles[name] = p
+	}
+	return p
+}
+
+// Get returns the value of key formatted as a string. Unset keys return "".
+func (p *Profile) Get(key string) (string, error) {
+	if name, ok := strings.CutPrefix(key, fieldKeyPrefix); ok {
+		return p.Fields[name], nil
+	}
+	switch key {
+	case "url":
+		return p.URL, nil
+	case "deployment":
+		return p.Deployment, nil
+	case "email":
+		return p.Email, nil
+	case "auth-method":
+		return p.AuthMethod, nil
+	case "username":
+		return p.Username, nil
+	case "oauth-consumer-key":
+		return p.OAuthConsumerKey, nil
+	case "oauth-private-key":
+		return p.OAuthPrivateKey, nil
+	case "project":
+		return p.Project, nil
+	case "closed-statuses":
+		return strings.Join(p.ClosedStatuses, ","), nil
+	case "output":
+		return p.Output, nil
+	case "auth-backend":
+		return p.AuthBackend, nil
+	case "credential-helper":
+		return p.CredentialHelper, nil
+	case "retries":
+		if p.Retries == nil {
+			return "", nil
+		}
+		return strconv.Itoa(*p.Retries), nil
+	case "retry-wait":
+		return p.RetryWait, nil
+	case "retry-max-wait":
+		return p.RetryMaxWait, nil
+	case "retry-unsafe":
+		if !p.RetryUnsafe {
+			return "", nil
+		}
+		return "true", nil
+	case "timeout":
+		return p.Timeout, nil
+	case "epic-children":
+		return p.EpicChildren, nil
+	case "ca-file":
+		return p.CAFile, nil
+	case "client-cert":
+		return p.ClientCert, nil
+	case "client-key":
+		return p.ClientKey, nil
+	case "insecure":
+		if !p.Insecure {
+			return "", nil
+		}
+		return "true", nil
+	case "proxy":
+		return p.Proxy, nil
+	case "no-proxy":
+		return p.NoProxy, nil
+	default:
+		return "", unknownKey(key)
+	}
+}
+
+// Set parses value and assigns it to key. An empty value unsets the key.
+func (p *Profile) Set(key, value string) error {
+	if name, ok := strings.CutPrefix(key, fieldKeyPrefix); ok && name != "" {
+		if value == "" {
+			delete(p.Fields, name)
+			return nil
+		}
+		if p.Fields == nil {
+			p.Fields = map[string]string{}
+		}
+		p.Fields[name] = value
+		return nil
+	}
+	switch key {
+	case "url":
+		p.URL = strings.TrimSuffix(value, "/")
+	case "deployment":
+		if value != "" {
+			if err := jira.ValidDeployment(value); err != nil {
+				return err
+			}
+		}
+		p.Deployment = value
+	case "email":
+		p.Email = value
+	case "auth-method":
+		if value != "" {
+			if err := jira.ValidAuthMethod(value); err != nil {
+				return err
+			}
+		}
+		p.AuthMethod = value
+	case "username":
+		p.Username = value
+	case "oauth-consumer-key":
+		p.OAuthConsumerKey = value
+	case "oauth-private-key":
+		p.OAuthPrivateKey = value
+	case "project":
+		p.Project = value
+	case "closed-statuses":
+		p.ClosedStatuses = SplitList(value)
+	case "output":
+		if value != "" {
+			if err := formatter.ValidFormat(value); err != nil {
+				return err
+			}
+		}
+		p.Output = value
+	case "auth-backend":
+		if value != "" {
+			if err := keychain.ValidBackend(value); err != nil {
+				return err
+			}
+		}
+		p.AuthBackend = value
+	case "credential-helper":
+		p.CredentialHelper = value
+	case "retries":
+		if value == "" {
+			p.Retries = nil
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("retries must be a non-negative integer, got %q", value)
+		}
+		p.Retries = &n
+	case "retry-wait":
+		if value != "" {
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("retry-wait must be a duration such as 500ms or 2s, got %q", value)
+			}
+		}
+		p.RetryWait = value
+	case "retry-max-wait":
+		if value != "" {
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("retry-max-wait must be a duration such as 30s or 2m, got %q", value)
+			}
+		}
+		p.RetryMaxWait = value
+	case "retry-unsafe":
+		if value == "" {
+			p.RetryUnsafe = false
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("retry-unsafe must be true or false, got %q", value)
+		}
+		p.RetryUnsafe = b
+	case "timeout":
+		if value != "" {
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("timeout must be a duration such as 30s or 2m, got %q", value)
+			}
+		}
+		p.Timeout = value
+	case "epic-children":
+		if value != "" {
+			if err := jira.ValidEpicChildren(value); err != nil {
+				return err
+			}
+		}
+		p.EpicChildren = value
+	case "ca-file":
+		p.CAFile = value
+	case "client-cert":
+		p.ClientCert = value
+	case "client-key":
+		p.ClientKey = value
+	case "insecure":
+		if value == "" {
+			p.Insecure = false
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("insecure must be true or false, got %q", value)
+		}
+		p.Insecure = b
+	case "proxy":
+		p.Proxy = value
+	case "no-proxy":
+		p.NoProxy = value
+	default:
+		return unknownKey(key)
+	}
+	return nil
+}
+
+// SetKeys returns the keys that have a value in p, in display order.
+func (p *Profile) SetKeys() []string {
+	var keys []string
+	for _, key := range Keys {
+		if value, err := p.Get(key); err == nil && value != "" {
+			keys = append(keys, key)
+		}
+	}
+	var fieldKeys []string
+	for name := range p.Fields {
+		fieldKeys = append(fieldKeys, fieldKeyPrefix+name)
+	}
+	sort.Strings(fieldKeys)
+	return append(keys, fieldKeys...)
+}
+
+// SplitList splits a comma-separated list, trimming blanks and dropping empty items.
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown config key: %q (valid keys: %s)", key, strings.Join(Keys, ", "))
+}
@@ -11,18 +11,52 @@ import (
 type Formatter interface {
 	FormatIssue(w io.Writer, issue *jira.Issue) error
 	FormatSearchResult(w io.Writer, result *jira.SearchResult) error
+	FormatTransitions(w io.Writer, key string, transitions []jira.Transition) error
+	FormatComments(w io.Writer, key string, comments *jira.Comments) error
+	FormatWorklogs(w io.Writer, key string, worklogs *jira.Worklogs) error
+	FormatAttachments(w io.Writer, key string, attachments []jira.Attachment) error
+	FormatLinkTypes(w io.Writer, types []jira.IssueLinkType) error
+}
+
+// Option configures optional formatter behaviour.
+type Option func(*options)
+
+type options struct {
+	markdownBodies bool
+}
+
+// WithMarkdownBodies makes the JSON formatter convert descriptions and
+// comments from Jira wiki markup to Markdown. The Markdown formatter always
+// converts them.
+func WithMarkdownBodies(enabled bool) Option {
+	return func(o *options) {
+		o.markdownBodies = enabled
+	}
 }
 
 // New creates a formatter for the given format name.
-func New(format string, baseURL string) (Formatter, error) {
+func New(format string, baseURL string, opts ...Option) (Formatter, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 	switch format {
 	case "json":
-		return &JSONFormatter{BaseURL: baseURL}, nil
+		return &JSONFormatter{BaseURL: baseURL, MarkdownBodies: o.markdownBodies}, nil
 	case "markdown", "md":
 		return &MarkdownFormatter{BaseURL: baseURL}, nil
 	case "text":
 		return &TextFormatter{BaseURL: baseURL}, nil
 	default:
-		return nil, fmt.Errorf("unknown output format: %q (use json, markdown, or text)", format)
+		return nil, ValidFormat(format)
+	}
+}
+
+// ValidFormat checks a format name for New.
+func ValidFormat(format string) error {
+	switch format {
+	case "json", "markdown", "md", "text":
+		return nil
 	}
+	return fmt.Errorf("unknown output format: %q (use json, markdown, or text)", format)
 }
@@ -2,38 +2,65 @@ package formatter
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/bentsolheim/jira-cli/internal/markup"
 )
 
 // JSONFormatter outputs issues as structured JSON.
 type JSONFormatter struct {
 	BaseURL string
+	// MarkdownBodies converts descriptions and comments from Jira wiki
+	// markup to Markdown.
+	MarkdownBodies bool
 }
 
 // agentIssue is a flattened, agent-friendly representation of a Jira issue.
 type agentIssue struct {
-	Key         string            `json:"key"`
-	Summary     string            `json:"summary"`
-	Status      string            `json:"status"`
-	Priority    string            `json:"priority,omitempty"`
-	Type        string            `json:"type"`
-	Assignee    string            `json:"assignee,omitempty"`
-	Reporter    string            `json:"reporter,omitempty"`
-	Project     string            `json:"project"`
-	Epic        string            `json:"epic,omitempty"`
-	Labels      []string          `json:"labels,omitempty"`
-	Components  []string          `json:"components,omitempty"`
-	Created     string            `json:"created"`
-	Updated     string            `json:"updated"`
-	Resolution  string            `json:"resolution,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Parent      string            `json:"parent,omitempty"`
-	ParentLink  string            `json:"parentLink,omitempty"`
-	Children    []agentChildIssue `json:"children,omitempty"`
-	Links       []agentLink       `json:"links,omitempty"`
-	Comments    []agentComment    `json:"comments,omitempty"`
+	Key          string             `json:"key"`
+	Summary      string             `json:"summary"`
+	Status       string             `json:"status"`
+	Priority     string             `json:"priority,omitempty"`
+	Type         string             `json:"type"`
+	Assignee     string             `json:"assignee,omitempty"`
+	Reporter     string             `json:"reporter,omitempty"`
+	Project      string             `json:"project"`
+	Epic         string             `json:"epic,omitempty"`
+	StoryPoints  *float64           `json:"storyPoints,omitempty"`
+	Sprints      []string           `json:"sprints,omitempty"`
+	Labels       []string           `json:"labels,omitempty"`
+	Components   []string           `json:"components,omitempty"`
+	FixVersions  []string           `json:"fixVersions,omitempty"`
+	Affects      []string           `json:"affectsVersions,omitempty"`
+	DueDate      string             `json:"dueDate,omitempty"`
+	Environment  string             `json:"environment,omitempty"`
+	TimeTracking *agentTimeTracking `json:"timeTracking,omitempty"`
+	Created      string             `json:"created"`
+	Updated      string             `json:"updated"`
+	Resolution   string             `json:"resolution,omitempty"`
+	Description  string             `json:"description,omitempty"`
+	Parent       string             `json:"parent,omitempty"`
+	ParentLink   string             `json:"parentLink,omitempty"`
+	Children     []agentChildIssue  `json:"children,omitempty"`
+	Links        []agentLink        `json:"links,omitempty"`
+	Attachments  []agentAttachment  `json:"attachments,omitempty"`
+	Comments     []agentComment     `json:"comments,omitempty"`
+
+	// Fields holds extra fields requested with --fields, keyed by field name.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// extra lists the same fields in request order for tabular output.
+	extra []agentField
+	// markdown is set when the description is already Markdown (Jira Cloud).
+	markdown bool
+}
+
+type agentField struct {
+	Name  string
+	Value interface{}
 }
 
 type agentChildIssue struct {
@@ -43,6 +70,8 @@ type agentChildIssue struct {
 	Type        string `json:"type,omitempty"`
 	Assignee    string `json:"assignee,omitempty"`
 	Description string `json:"description,omitempty"`
+
+	markdown bool
 }
 
 type agentLink struct {
@@ -52,9 +81,77 @@ type agentLink struct {
 }
 
 type agentComment struct {
-	Author  string `json:"author"`
-	Created string `json:"created"`
-	Body    string `json:"body"`
+	ID         string `json:"id,omitempty"`
+	Author     string `json:"author"`
+	Created    string `json:"created"`
+	Visibility string `json:"visibility,omitempty"`
+	Body       string `json:"body"`
+
+	markdown bool
+}
+
+type agentTimeTracking struct {
+	OriginalEstimate  string `json:"originalEstimate,omitempty"`
+	RemainingEstimate string `json:"remainingEstimate,omitempty"`
+	TimeSpent         string `json:"timeSpent,omitempty"`
+}
+
+type agentWorklog struct {
+	ID               string `json:"id"`
+	Author           string `json:"author"`
+	Started          string `json:"started"`
+	TimeSpent        string `json:"timeSpent"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+type agentWorklogs struct {
+	Key              string         `json:"key"`
+	Total            int            `json:"total"`
+	TimeSpent        string         `json:"timeSpent"`
+	TimeSpentSeconds int            `json:"timeSpentSeconds"`
+	Worklogs         []agentWorklog `json:"worklogs"`
+}
+
+type agentAttachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Created  string `json:"created"`
+	// Content is the attachment text, when downloaded with --include-attachments.
+	Content *string `json:"content,omitempty"`
+}
+
+type agentAttachments struct {
+	Key         string            `json:"key"`
+	Attachments []agentAttachment `json:"attachments"`
+}
+
+type agentLinkType struct {
+	Name    string `json:"name"`
+	Outward string `json:"outward"`
+	Inward  string `json:"inward"`
+}
+
+type agentComments struct {
+	Key      string         `json:"key"`
+	Total    int            `json:"total"`
+	Count    int            `json:"count"`
+	Comments []agentComment `json:"comments"`
+}
+
+type agentTransitions struct {
+	Key         string            `json:"key"`
+	Transitions []agentTransition `json:"transitions"`
+}
+
+type agentTransition struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	To             string   `json:"to"`
+	RequiredFields []string `json:"requiredFields,omitempty"`
 }
 
 type agentSearchResult struct {
@@ -70,6 +167,7 @@ func toAgentIssue(issue *jira.Issue) agentIssue {
 		Description: issue.Fields.Description,
 		Created:     issue.Fields.Created,
 		Updated:     issue.Fields.Updated,
+		markdown:    issue.Fields.Markdown,
 	}
 
 	if issue.Fields.Status != nil {
@@ -103,17 +201,35 @@ func toAgentIssue(issue *jira.Issue) agentIssue {
 		ai.ParentLink = issue.Fields.ParentLink
 	}
 
+	ai.StoryPoints = issue.Fields.StoryPoints
+	ai.Sprints = issue.Fields.Sprints
 	ai.Labels = issue.Fields.Labels
 
 	for _, c := range issue.Fields.Components {
 		ai.Components = append(ai.Components, c.Name)
 	}
+	for _, v := range issue.Fields.FixVersions {
+		ai.FixVersions = append(ai.FixVersions, v.Name)
+	}
+	for _, v := range issue.Fields.Versions {
+		ai.Affects = append(ai.Affects, v.Name)
+	}
+	ai.DueDate = issue.Fields.DueDate
+	ai.Environment = issue.Fields.Environment
+	if tt := issue.Fields.TimeTracking; tt != nil && (tt.OriginalEstimate != "" || tt.RemainingEstimate != "" || tt.TimeSpent != "") {
+		ai.TimeTracking = &agentTimeTracking{
+			OriginalEstimate:  tt.OriginalEstimate,
+			RemainingEstimate: tt.RemainingEstimate,
+			TimeSpent:         tt.TimeSpent,
+		}
+	}
 
 	for _, sub := range issue.Fields.Subtasks {
 		child := agentChildIssue{
 			Key:         sub.Key,
 			Summary:     sub.Fields.Summary,
 			Description: sub.Fields.Description,
+			markdown:    sub.Fields.Markdown,
 		}
 		if sub.Fields.Status != nil {
 			child.Status = sub.Fields.Status.Name
@@ -133,6 +249,7 @@ func toAgentIssue(issue *jira.Issue) agentIssue {
 			Key:         epicChild.Key,
 			Summary:     epicChild.Fields.Summary,
 			Description: epicChild.Fields.Description,
+			markdown:    epicChild.Fields.Markdown,
 		}
 		if epicChild.Fields.Status != nil {
 			child.Status = epicChild.Fields.Status.Name
@@ -154,6 +271,7 @@ func toAgentIssue(issue *jira.Issue) agentIssue {
 				Key:         link.InwardIssue.Key,
 				Summary:     link.InwardIssue.Fields.Summary,
 				Description: link.InwardIssue.Fields.Description,
+				markdown:    link.InwardIssue.Fields.Markdown,
 			}
 			if link.InwardIssue.Fields.Status != nil {
 				child.Status = link.InwardIssue.Fields.Status.Name
@@ -179,24 +297,225 @@ func toAgentIssue(issue *jira.Issue) agentIssue {
 		ai.Links = append(ai.Links, al)
 	}
 
+	for _, a := range issue.Fields.Attachments {
+		ai.Attachments = append(ai.Attachments, toAgentAttachment(a))
+	}
+
+	for _, fv := range issue.ExtraFields {
+		var value interface{}
+		if fv.Raw != nil {
+			value = simplifyFieldValue(fv.Raw)
+		}
+		if ai.Fields == nil {
+			ai.Fields = map[string]interface{}{}
+		}
+		ai.Fields[fv.Field.Name] = value
+		ai.extra = append(ai.extra, agentField{Name: fv.Field.Name, Value: value})
+	}
+
 	if issue.Fields.Comment != nil {
 		for _, c := range issue.Fields.Comment.Comments {
-			ac := agentComment{
-				Body:    c.Body,
-				Created: c.Created,
+			ai.Comments = append(ai.Comments, toAgentComment(c))
+		}
+	}
+
+	return ai
+}
+
+// convertMarkup converts the description, child descriptions and comments
+// from Jira wiki markup to Markdown. Those already in Markdown are kept.
+func (ai *agentIssue) convertMarkup() {
+	ai.Description = toMarkdown(ai.Description, ai.markdown)
+	for i, c := range ai.Children {
+		ai.Children[i].Description = toMarkdown(c.Description, c.markdown)
+	}
+	for i, c := range ai.Comments {
+		ai.Comments[i].Body = toMarkdown(c.Body, c.markdown)
+	}
+}
+
+// toMarkdown converts s from Jira wiki markup to Markdown, unless markdown
+// says it already is.
+func toMarkdown(s string, markdown bool) string {
+	if markdown {
+		return s
+	}
+	return markup.WikiToMarkdown(s)
+}
+
+func toAgentComment(c jira.Comment) agentComment {
+	ac := agentComment{
+		ID:       c.ID,
+		Body:     c.Body,
+		Created:  c.Created,
+		markdown: c.Markdown,
+	}
+	if c.Author != nil {
+		ac.Author = c.Author.DisplayName
+	}
+	if c.Visibility != nil {
+		ac.Visibility = fmt.Sprintf("%s: %s", c.Visibility.Type, c.Visibility.Value)
+	}
+	return ac
+}
+
+func toAgentComments(key string, comments *jira.Comments) agentComments {
+	ac := agentComments{
+		Key:      key,
+		Total:    comments.Total,
+		Count:    len(comments.Comments),
+		Comments: []agentComment{},
+	}
+	for _, c := range comments.Comments {
+		ac.Comments = append(ac.Comments, toAgentComment(c))
+	}
+	return ac
+}
+
+func toAgentWorklogs(key string, worklogs *jira.Worklogs) agentWorklogs {
+	aw := agentWorklogs{
+		Key:      key,
+		Total:    worklogs.Total,
+		Worklogs: []agentWorklog{},
+	}
+	for _, wl := range worklogs.Worklogs {
+		entry := agentWorklog{
+			ID:               wl.ID,
+			Started:          wl.Started,
+			TimeSpent:        wl.TimeSpent,
+			TimeSpentSeconds: wl.TimeSpentSeconds,
+			Comment:          wl.Comment,
+		}
+		if wl.Author != nil {
+			entry.Author = wl.Author.DisplayName
+		}
+		if entry.TimeSpent == "" {
+			entry.TimeSpent = jira.FormatDuration(wl.TimeSpentSeconds)
+		}
+		aw.TimeSpentSeconds += wl.TimeSpentSeconds
+		aw.Worklogs = append(aw.Worklogs, entry)
+	}
+	aw.TimeSpent = jira.FormatDuration(aw.TimeSpentSeconds)
+	return aw
+}
+
+func toAgentAttachment(a jira.Attachment) agentAttachment {
+	aa := agentAttachment{
+		ID:       a.ID,
+		Filename: a.Filename,
+		Size:     a.Size,
+		MimeType: a.MimeType,
+		Created:  a.Created,
+		Content:  a.Text,
+	}
+	if a.Author != nil {
+		aa.Author = a.Author.DisplayName
+	}
+	return aa
+}
+
+func toAgentAttachments(key string, attachments []jira.Attachment) agentAttachments {
+	aa := agentAttachments{Key: key, Attachments: []agentAttachment{}}
+	for _, a := range attachments {
+		aa.Attachments = append(aa.Attachments, toAgentAttachment(a))
+	}
+	return aa
+}
+
+// formatSize renders a byte count with a binary unit, e.g. "12.3 KB".
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+func toAgentTransitions(key string, transitions []jira.Transition) agentTransitions {
+	at := agentTransitions{Key: key, Transitions: []agentTransition{}}
+	for _, t := range transitions {
+		tr := agentTransition{ID: t.ID, Name: t.Name}
+		if t.To != nil {
+			tr.To = t.To.Name
+		}
+		for _, field := range t.Fields {
+			if field.Required {
+				tr.RequiredFields = append(tr.RequiredFields, field.Name)
+			}
+		}
+		sort.Strings(tr.RequiredFields)
+		at.Transitions = append(at.Transitions, tr)
+	}
+	return at
+}
+
+// simplifyFieldValue decodes a raw field value and reduces Jira's option, user
+// and version objects to their display value.
+func simplifyFieldValue(raw json.RawMessage) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	return simplifyValue(v)
+}
+
+func simplifyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if value, ok := t["value"]; ok {
+			if child, ok := t["child"].(map[string]interface{}); ok {
+				return fmt.Sprintf("%v / %v", value, child["value"])
 			}
-			if c.Author != nil {
-				ac.Author = c.Author.DisplayName
+			return value
+		}
+		for _, key := range []string{"displayName", "name", "key"} {
+			if s, ok := t[key]; ok {
+				return s
 			}
-			ai.Comments = append(ai.Comments, ac)
 		}
+		return t
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			out[i] = simplifyValue(item)
+		}
+		return out
 	}
+	return v
+}
 
-	return ai
+// fieldString renders a simplified field value on a single line.
+func fieldString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return fmt.Sprintf("%g", t)
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			parts[i] = fieldString(item)
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		data, _ := json.Marshal(t)
+		return string(data)
+	}
+	return fmt.Sprint(v)
 }
 
 func (f *JSONFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 	ai := toAgentIssue(issue)
+	if f.MarkdownBodies {
+		ai.convertMarkup()
+	}
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(ai)
@@ -208,9 +527,55 @@ func (f *JSONFormatter) FormatSearchResult(w io.Writer, result *jira.SearchResul
 		Count: len(result.Issues),
 	}
 	for _, issue := range result.Issues {
-		ar.Issues = append(ar.Issues, toAgentIssue(&issue))
+		ai := toAgentIssue(&issue)
+		if f.MarkdownBodies {
+			ai.convertMarkup()
+		}
+		ar.Issues = append(ar.Issues, ai)
 	}
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(ar)
 }
+
+func (f *JSONFormatter) FormatTransitions(w io.Writer, key string, transitions []jira.Transition) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toAgentTransitions(key, transitions))
+}
+
+func (f *JSONFormatter) FormatComments(w io.Writer, key string, comments *jira.Comments) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	ac := toAgentComments(key, comments)
+	if f.MarkdownBodies {
+		for i, c := range ac.Comments {
+			ac.Comments[i].Body = toMarkdown(c.Body, c.markdown)
+		}
+	}
+	return enc.Encode(ac)
+}
+
+func (f *JSONFormatter) FormatWorklogs(w io.Writer, key string, worklogs *jira.Worklogs) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toAgentWorklogs(key, worklogs))
+}
+
+func (f *JSONFormatter) FormatAttachments(w io.Writer, key string, attachments []jira.Attachment) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toAgentAttachments(key, attachments))
+}
+
+func (f *JSONFormatter) FormatLinkTypes(w io.Writer, types []jira.IssueLinkType) error {
+	out := struct {
+		LinkTypes []agentLinkType `json:"linkTypes"`
+	}{LinkTypes: []agentLinkType{}}
+	for _, t := range types {
+		out.LinkTypes = append(out.LinkTypes, agentLinkType{Name: t.Name, Outward: t.Outward, Inward: t.Inward})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -3,10 +3,12 @@ package formatter
 import (
 	"fmt"
 	"io"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/bentsolheim/jira-cli/internal/jira"
+	"github.com/bentsolheim/jira-cli/internal/markup"
 )
 
 // MarkdownFormatter outputs issues as Markdown, suitable for LLM/agent context.
@@ -42,18 +44,50 @@ func (f *MarkdownFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 	if ai.Epic != "" {
 		b.WriteString(fmt.Sprintf("- **Epic:** [%s](%s/browse/%s)\n", ai.Epic, f.BaseURL, ai.Epic))
 	}
+	if ai.StoryPoints != nil {
+		b.WriteString(fmt.Sprintf("- **Story Points:** %g\n", *ai.StoryPoints))
+	}
+	if len(ai.Sprints) > 0 {
+		b.WriteString(fmt.Sprintf("- **Sprint:** %s\n", strings.Join(ai.Sprints, ", ")))
+	}
 	if len(ai.Labels) > 0 {
 		b.WriteString(fmt.Sprintf("- **Labels:** %s\n", strings.Join(ai.Labels, ", ")))
 	}
 	if len(ai.Components) > 0 {
 		b.WriteString(fmt.Sprintf("- **Components:** %s\n", strings.Join(ai.Components, ", ")))
 	}
+	if len(ai.FixVersions) > 0 {
+		b.WriteString(fmt.Sprintf("- **Fix Versions:** %s\n", strings.Join(ai.FixVersions, ", ")))
+	}
+	if len(ai.Affects) > 0 {
+		b.WriteString(fmt.Sprintf("- **Affects Versions:** %s\n", strings.Join(ai.Affects, ", ")))
+	}
+	if ai.DueDate != "" {
+		b.WriteString(fmt.Sprintf("- **Due Date:** %s\n", ai.DueDate))
+	}
+	if ai.Environment != "" {
+		b.WriteString(fmt.Sprintf("- **Environment:** %s\n", ai.Environment))
+	}
+	if tt := ai.TimeTracking; tt != nil {
+		if tt.OriginalEstimate != "" {
+			b.WriteString(fmt.Sprintf("- **Original Estimate:** %s\n", tt.OriginalEstimate))
+		}
+		if tt.RemainingEstimate != "" {
+			b.WriteString(fmt.Sprintf("- **Remaining Estimate:** %s\n", tt.RemainingEstimate))
+		}
+		if tt.TimeSpent != "" {
+			b.WriteString(fmt.Sprintf("- **Time Spent:** %s\n", tt.TimeSpent))
+		}
+	}
+	for _, ef := range ai.extra {
+		b.WriteString(fmt.Sprintf("- **%s:** %s\n", ef.Name, fieldString(ef.Value)))
+	}
 	b.WriteString(fmt.Sprintf("- **Created:** %s\n", ai.Created))
 	b.WriteString(fmt.Sprintf("- **Updated:** %s\n", ai.Updated))
 
 	if ai.Description != "" {
 		b.WriteString("\n## Description\n\n")
-		b.WriteString(ai.Description)
+		b.WriteString(markdownBody(ai.Description, ai.markdown, 2))
 		b.WriteString("\n")
 	}
 
@@ -79,7 +113,7 @@ func (f *MarkdownFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 				b.WriteString(fmt.Sprintf("- **Assignee:** %s\n", c.Assignee))
 			}
 			if c.Description != "" {
-				b.WriteString(fmt.Sprintf("\n%s\n", c.Description))
+				b.WriteString(fmt.Sprintf("\n%s\n", markdownBody(c.Description, c.markdown, 3)))
 			}
 			if i < len(ai.Children)-1 {
 				b.WriteString("\n\n\n")
@@ -94,10 +128,26 @@ func (f *MarkdownFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 		}
 	}
 
+	if len(ai.Attachments) > 0 {
+		b.WriteString("\n## Attachments\n\n")
+		for _, a := range ai.Attachments {
+			b.WriteString(fmt.Sprintf("- **%s** (%s", a.Filename, formatSize(a.Size)))
+			if a.MimeType != "" {
+				b.WriteString(", " + a.MimeType)
+			}
+			b.WriteString(")\n")
+		}
+		for _, a := range ai.Attachments {
+			if a.Content != nil {
+				writeMarkdownAttachment(&b, a)
+			}
+		}
+	}
+
 	if len(ai.Comments) > 0 {
 		b.WriteString("\n## Comments\n\n")
 		for _, c := range ai.Comments {
-			b.WriteString(fmt.Sprintf("### %s (%s)\n\n%s\n\n", c.Author, c.Created, c.Body))
+			writeMarkdownComment(&b, c)
 		}
 	}
 
@@ -105,6 +155,41 @@ func (f *MarkdownFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 	return err
 }
 
+// writeMarkdownAttachment writes the content of a text attachment as a fenced
+// code block under a level-3 heading.
+func writeMarkdownAttachment(b *strings.Builder, a agentAttachment) {
+	content := strings.TrimRight(*a.Content, "\n")
+	// The fence must be longer than any backtick run inside the content.
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	lang := strings.TrimPrefix(strings.ToLower(path.Ext(a.Filename)), ".")
+	b.WriteString(fmt.Sprintf("\n### %s\n\n%s%s\n%s\n%s\n", a.Filename, fence, lang, content, fence))
+}
+
+// writeMarkdownComment writes a comment as a level-3 section.
+func writeMarkdownComment(b *strings.Builder, c agentComment) {
+	b.WriteString(fmt.Sprintf("### %s (%s)\n\n", c.Author, c.Created))
+	if c.ID != "" {
+		b.WriteString(fmt.Sprintf("- **ID:** %s\n", c.ID))
+	}
+	if c.Visibility != "" {
+		b.WriteString(fmt.Sprintf("- **Visibility:** %s\n", c.Visibility))
+	}
+	if c.ID != "" || c.Visibility != "" {
+		b.WriteString("\n")
+	}
+	b.WriteString(markdownBody(c.Body, c.markdown, 3) + "\n\n")
+}
+
+// markdownBody converts a description or comment from Jira wiki markup,
+// unless isMarkdown says it already is Markdown, and nests its headings below
+// the given section level.
+func markdownBody(s string, isMarkdown bool, level int) string {
+	return markup.DemoteHeadings(toMarkdown(s, isMarkdown), level)
+}
+
 // writeAlignedTable writes a markdown table with columns padded to equal width.
 func writeAlignedTable(b *strings.Builder, headers []string, rows [][]string) {
 	// Calculate max width for each column
@@ -212,6 +297,19 @@ func shortenName(name string) string {
 	return firstName
 }
 
+// extraFieldNames returns the names of the --fields columns in a search result.
+// SetExtraFields is applied uniformly, so the first issue is representative.
+func extraFieldNames(result *jira.SearchResult) []string {
+	if len(result.Issues) == 0 {
+		return nil
+	}
+	var names []string
+	for _, fv := range result.Issues[0].ExtraFields {
+		names = append(names, fv.Field.Name)
+	}
+	return names
+}
+
 // parentOrEpic returns the parent key or epic key for display in tables.
 func parentOrEpic(ai agentIssue) string {
 	if ai.Parent != "" {
@@ -225,17 +323,108 @@ func (f *MarkdownFormatter) FormatSearchResult(w io.Writer, result *jira.SearchR
 
 	b.WriteString(fmt.Sprintf("# Search Results (%d of %d)\n\n", len(result.Issues), result.Total))
 
-	headers := []string{"Created", "Updated", "Key", "Parent", "Type", "Status", "Reporter", "Assignee", "Summary"}
+	headers := []string{"Created", "Updated", "Key", "Parent", "Type", "Status", "Reporter", "Assignee"}
+	headers = append(headers, extraFieldNames(result)...)
+	headers = append(headers, "Summary")
 	var rows [][]string
 	for _, issue := range result.Issues {
 		ai := toAgentIssue(&issue)
-		rows = append(rows, []string{
+		row := []string{
 			formatShortDate(ai.Created), formatShortDate(ai.Updated),
-			ai.Key, parentOrEpic(ai), ai.Type, ai.Status, shortenName(ai.Reporter), shortenName(ai.Assignee), ai.Summary,
-		})
+			ai.Key, parentOrEpic(ai), ai.Type, ai.Status, shortenName(ai.Reporter), shortenName(ai.Assignee),
+		}
+		for _, ef := range ai.extra {
+			row = append(row, fieldString(ef.Value))
+		}
+		rows = append(rows, append(row, ai.Summary))
 	}
 	writeAlignedTable(&b, headers, rows)
 
 	_, err := io.WriteString(w, b.String())
 	return err
 }
+
+func (f *MarkdownFormatter) FormatTransitions(w io.Writer, key string, transitions []jira.Transition) error {
+	at := toAgentTransitions(key, transitions)
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("# Transitions for [%s](%s/browse/%s)\n\n", key, f.BaseURL, key))
+
+	headers := []string{"ID", "Transition", "To Status", "Required Fields"}
+	var rows [][]string
+	for _, t := range at.Transitions {
+		rows = append(rows, []string{t.ID, t.Name, t.To, strings.Join(t.RequiredFields, ", ")})
+	}
+	writeAlignedTable(&b, headers, rows)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (f *MarkdownFormatter) FormatComments(w io.Writer, key string, comments *jira.Comments) error {
+	ac := toAgentComments(key, comments)
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("# Comments on [%s](%s/browse/%s) (%d of %d)\n\n", key, f.BaseURL, key, ac.Count, ac.Total))
+	for _, c := range ac.Comments {
+		writeMarkdownComment(&b, c)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (f *MarkdownFormatter) FormatWorklogs(w io.Writer, key string, worklogs *jira.Worklogs) error {
+	aw := toAgentWorklogs(key, worklogs)
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("# Worklogs on [%s](%s/browse/%s)\n\n", key, f.BaseURL, key))
+
+	headers := []string{"ID", "Started", "Author", "Time Spent", "Comment"}
+	var rows [][]string
+	for _, wl := range aw.Worklogs {
+		rows = append(rows, []string{wl.ID, formatShortDate(wl.Started), wl.Author, wl.TimeSpent, singleLine(wl.Comment)})
+	}
+	writeAlignedTable(&b, headers, rows)
+	b.WriteString(fmt.Sprintf("\n**Total:** %s\n", aw.TimeSpent))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (f *MarkdownFormatter) FormatAttachments(w io.Writer, key string, attachments []jira.Attachment) error {
+	aa := toAgentAttachments(key, attachments)
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("# Attachments on [%s](%s/browse/%s)\n\n", key, f.BaseURL, key))
+
+	headers := []string{"ID", "Name", "Size", "Author", "Created"}
+	var rows [][]string
+	for _, a := range aa.Attachments {
+		rows = append(rows, []string{a.ID, a.Filename, formatSize(a.Size), a.Author, formatShortDate(a.Created)})
+	}
+	writeAlignedTable(&b, headers, rows)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (f *MarkdownFormatter) FormatLinkTypes(w io.Writer, types []jira.IssueLinkType) error {
+	var b strings.Builder
+	b.WriteString("# Issue Link Types\n\n")
+
+	headers := []string{"Name", "Outward", "Inward"}
+	var rows [][]string
+	for _, t := range types {
+		rows = append(rows, []string{t.Name, t.Outward, t.Inward})
+	}
+	writeAlignedTable(&b, headers, rows)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// singleLine joins the lines of s with spaces, for use in a table cell.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -41,12 +41,47 @@ func (f *TextFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 	if ai.ParentLink != "" {
 		fmt.Fprintf(tw, "Parent Link:\t%s\n", ai.ParentLink)
 	}
+	if ai.Epic != "" {
+		fmt.Fprintf(tw, "Epic:\t%s\n", ai.Epic)
+	}
+	if ai.StoryPoints != nil {
+		fmt.Fprintf(tw, "Story Points:\t%g\n", *ai.StoryPoints)
+	}
+	if len(ai.Sprints) > 0 {
+		fmt.Fprintf(tw, "Sprint:\t%s\n", strings.Join(ai.Sprints, ", "))
+	}
 	if len(ai.Labels) > 0 {
 		fmt.Fprintf(tw, "Labels:\t%s\n", strings.Join(ai.Labels, ", "))
 	}
 	if len(ai.Components) > 0 {
 		fmt.Fprintf(tw, "Components:\t%s\n", strings.Join(ai.Components, ", "))
 	}
+	if len(ai.FixVersions) > 0 {
+		fmt.Fprintf(tw, "Fix Versions:\t%s\n", strings.Join(ai.FixVersions, ", "))
+	}
+	if len(ai.Affects) > 0 {
+		fmt.Fprintf(tw, "Affects Versions:\t%s\n", strings.Join(ai.Affects, ", "))
+	}
+	if ai.DueDate != "" {
+		fmt.Fprintf(tw, "Due Date:\t%s\n", ai.DueDate)
+	}
+	if ai.Environment != "" {
+		fmt.Fprintf(tw, "Environment:\t%s\n", ai.Environment)
+	}
+	if tt := ai.TimeTracking; tt != nil {
+		if tt.OriginalEstimate != "" {
+			fmt.Fprintf(tw, "Original Estimate:\t%s\n", tt.OriginalEstimate)
+		}
+		if tt.RemainingEstimate != "" {
+			fmt.Fprintf(tw, "Remaining Estimate:\t%s\n", tt.RemainingEstimate)
+		}
+		if tt.TimeSpent != "" {
+			fmt.Fprintf(tw, "Time Spent:\t%s\n", tt.TimeSpent)
+		}
+	}
+	for _, ef := range ai.extra {
+		fmt.Fprintf(tw, "%s:\t%s\n", ef.Name, fieldString(ef.Value))
+	}
 	fmt.Fprintf(tw, "Created:\t%s\n", ai.Created)
 	fmt.Fprintf(tw, "Updated:\t%s\n", ai.Updated)
 	tw.Flush()
@@ -75,10 +110,17 @@ func (f *TextFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 		}
 	}
 
+	if len(ai.Attachments) > 0 {
+		b.WriteString("\nAttachments:\n")
+		for _, a := range ai.Attachments {
+			b.WriteString(fmt.Sprintf("  - %s (%s)\n", a.Filename, formatSize(a.Size)))
+		}
+	}
+
 	if len(ai.Comments) > 0 {
 		b.WriteString("\nComments:\n")
 		for _, c := range ai.Comments {
-			b.WriteString(fmt.Sprintf("\n  %s (%s):\n  %s\n", c.Author, c.Created, c.Body))
+			writeTextComment(&b, c)
 		}
 	}
 
@@ -89,16 +131,110 @@ func (f *TextFormatter) FormatIssue(w io.Writer, issue *jira.Issue) error {
 func (f *TextFormatter) FormatSearchResult(w io.Writer, result *jira.SearchResult) error {
 	fmt.Fprintf(w, "Results: %d of %d\n\n", len(result.Issues), result.Total)
 
+	headers := []string{"CREATED", "UPDATED", "KEY", "PARENT", "TYPE", "STATUS", "REPORTER", "ASSIGNEE"}
+	for _, name := range extraFieldNames(result) {
+		headers = append(headers, strings.ToUpper(name))
+	}
+	headers = append(headers, "SUMMARY")
+
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(tw, "CREATED\tUPDATED\tKEY\tPARENT\tTYPE\tSTATUS\tREPORTER\tASSIGNEE\tSUMMARY")
-	fmt.Fprintln(tw, "-------\t-------\t---\t------\t----\t------\t--------\t--------\t-------")
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	underlines := make([]string, len(headers))
+	for i, h := range headers {
+		underlines[i] = strings.Repeat("-", len(h))
+	}
+	fmt.Fprintln(tw, strings.Join(underlines, "\t"))
 
 	for _, issue := range result.Issues {
 		ai := toAgentIssue(&issue)
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		row := []string{
 			formatShortDate(ai.Created), formatShortDate(ai.Updated),
-			ai.Key, parentOrEpic(ai), ai.Type, ai.Status, shortenName(ai.Reporter), shortenName(ai.Assignee), ai.Summary)
+			ai.Key, parentOrEpic(ai), ai.Type, ai.Status, shortenName(ai.Reporter), shortenName(ai.Assignee),
+		}
+		for _, ef := range ai.extra {
+			row = append(row, fieldString(ef.Value))
+		}
+		row = append(row, ai.Summary)
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func (f *TextFormatter) FormatTransitions(w io.Writer, key string, transitions []jira.Transition) error {
+	at := toAgentTransitions(key, transitions)
+	fmt.Fprintf(w, "Transitions for %s:\n\n", key)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTRANSITION\tTO STATUS\tREQUIRED FIELDS")
+	fmt.Fprintln(tw, "--\t----------\t---------\t---------------")
+	for _, t := range at.Transitions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", t.ID, t.Name, t.To, strings.Join(t.RequiredFields, ", "))
+	}
+	return tw.Flush()
+}
+
+// writeTextComment writes an indented comment with its author and date.
+func writeTextComment(b *strings.Builder, c agentComment) {
+	header := fmt.Sprintf("%s (%s)", c.Author, c.Created)
+	if c.ID != "" {
+		header += " #" + c.ID
+	}
+	if c.Visibility != "" {
+		header += " [" + c.Visibility + "]"
+	}
+	b.WriteString(fmt.Sprintf("\n  %s:\n  %s\n", header, c.Body))
+}
+
+func (f *TextFormatter) FormatComments(w io.Writer, key string, comments *jira.Comments) error {
+	ac := toAgentComments(key, comments)
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Comments on %s: %d of %d\n", key, ac.Count, ac.Total))
+	for _, c := range ac.Comments {
+		writeTextComment(&b, c)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (f *TextFormatter) FormatWorklogs(w io.Writer, key string, worklogs *jira.Worklogs) error {
+	aw := toAgentWorklogs(key, worklogs)
+	fmt.Fprintf(w, "Worklogs on %s:\n\n", key)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTARTED\tAUTHOR\tTIME SPENT\tCOMMENT")
+	fmt.Fprintln(tw, "--\t-------\t------\t----------\t-------")
+	for _, wl := range aw.Worklogs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", wl.ID, formatShortDate(wl.Started), shortenName(wl.Author), wl.TimeSpent, singleLine(wl.Comment))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\nTotal: %s\n", aw.TimeSpent)
+	return err
+}
+
+func (f *TextFormatter) FormatAttachments(w io.Writer, key string, attachments []jira.Attachment) error {
+	aa := toAgentAttachments(key, attachments)
+	fmt.Fprintf(w, "Attachments on %s:\n\n", key)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSIZE\tAUTHOR\tCREATED")
+	fmt.Fprintln(tw, "--\t----\t----\t------\t-------")
+	for _, a := range aa.Attachments {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", a.ID, a.Filename, formatSize(a.Size), shortenName(a.Author), formatShortDate(a.Created))
 	}
+	return tw.Flush()
+}
 
+func (f *TextFormatter) FormatLinkTypes(w io.Writer, types []jira.IssueLinkType) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tOUTWARD\tINWARD")
+	fmt.Fprintln(tw, "----\t-------\t------")
+	for _, t := range types {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", t.Name, t.Outward, t.Inward)
+	}
 	return tw.Flush()
 }
@@ -0,0 +1,178 @@
+// Package graph builds dependency graphs of Jira issues from their links,
+// subtasks and epic/parent hierarchy, and renders them as DOT or Mermaid.
+package graph
+
+import (
+	"context"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+)
+
+// Node is an issue in the graph.
+type Node struct {
+	Key     string
+	Summary string
+	Status  string
+	// Category is the status category key: "new", "indeterminate" or "done".
+	Category string
+}
+
+// Edge is a directed, labelled relation between two issues.
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// Graph holds nodes in discovery order and de-duplicated edges.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+
+	nodes map[string]int
+	edges map[Edge]bool
+}
+
+// New returns an empty graph.
+func New() *Graph {
+	return &Graph{nodes: map[string]int{}, edges: map[Edge]bool{}}
+}
+
+// FetchFunc fetches a single issue with its links and subtasks.
+type FetchFunc func(ctx context.Context, key string) (*jira.Issue, error)
+
+// Crawl walks breadth-first from roots, following links, subtasks, parents,
+// epic links and parent links, and epic children when fetch includes them,
+// up to depth hops away. Issues at
+// the edge of the crawl are included as nodes using the data embedded in
+// their neighbours.
+func Crawl(ctx context.Context, fetch FetchFunc, roots []string, depth int) (*Graph, error) {
+	g := New()
+	seen := map[string]bool{}
+	frontier := roots
+	for level := 0; len(frontier) > 0 && level <= depth; level++ {
+		var next []string
+		for _, key := range frontier {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			issue, err := fetch(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			for _, neighbour := range g.AddIssue(issue) {
+				if !seen[neighbour] {
+					next = append(next, neighbour)
+				}
+			}
+		}
+		frontier = next
+	}
+	return g, nil
+}
+
+// AddIssue adds issue and its relations to the graph and returns the keys
+// of the related issues.
+func (g *Graph) AddIssue(issue *jira.Issue) []string {
+	g.addNode(issue, true)
+	key := issue.Key
+	var related []string
+	relate := func(other *jira.Issue, from, to, label string) {
+		if from == to {
+			return
+		}
+		if other != nil {
+			g.addNode(other, false)
+		}
+		g.addEdge(from, to, label)
+		if from == key {
+			related = append(related, to)
+		} else {
+			related = append(related, from)
+		}
+	}
+
+	for _, link := range issue.Fields.IssueLinks {
+		// Normalize to the outward direction so the link recorded on both
+		// issues yields a single edge.
+		switch {
+		case link.OutwardIssue != nil:
+			relate(link.OutwardIssue, key, link.OutwardIssue.Key, link.Type.Outward)
+		case link.InwardIssue != nil:
+			relate(link.InwardIssue, link.InwardIssue.Key, key, link.Type.Outward)
+		}
+	}
+	for i := range issue.Fields.Subtasks {
+		sub := &issue.Fields.Subtasks[i]
+		relate(sub, key, sub.Key, "subtask")
+	}
+	if parent := issue.Fields.Parent; parent != nil {
+		relate(parent, parent.Key, key, parentLabel(issue, parent))
+	}
+	for i := range issue.EpicChildren {
+		child := &issue.EpicChildren[i]
+		relate(child, key, child.Key, "epic")
+	}
+	if epic := issue.Fields.EpicLink; epic != "" {
+		relate(nil, epic, key, "epic")
+	}
+	if parent := issue.Fields.ParentLink; parent != "" {
+		relate(nil, parent, key, "parent link")
+	}
+	return related
+}
+
+// parentLabel labels the edge from the parent field: "epic" for an epic
+// (the Cloud hierarchy), "subtask" for the parent of a subtask and "parent"
+// for other levels, such as an initiative above an epic.
+func parentLabel(issue, parent *jira.Issue) string {
+	switch {
+	case parent.Fields.IssueType != nil && jira.IsEpicType(parent.Fields.IssueType.Name):
+		return "epic"
+	case issue.Fields.IssueType == nil || issue.Fields.IssueType.Subtask:
+		return "subtask"
+	default:
+		return "parent"
+	}
+}
+
+// addNode adds or, when full, replaces the node for issue. Partial data
+// from an embedded issue never overwrites a fetched issue.
+func (g *Graph) addNode(issue *jira.Issue, full bool) {
+	n := Node{Key: issue.Key, Summary: issue.Fields.Summary}
+	if s := issue.Fields.Status; s != nil {
+		n.Status = s.Name
+		if s.Category != nil {
+			n.Category = s.Category.Key
+		}
+	}
+
+	i, ok := g.nodes[issue.Key]
+	if !ok {
+		g.nodes[issue.Key] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, n)
+		return
+	}
+	if full || g.Nodes[i].Status == "" {
+		g.Nodes[i] = n
+	}
+}
+
+func (g *Graph) addEdge(from, to, label string) {
+	if _, ok := g.nodes[from]; !ok {
+		g.nodes[from] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, Node{Key: from})
+	}
+	if _, ok := g.nodes[to]; !ok {
+		g.nodes[to] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, Node{Key: to})
+	}
+	e := Edge{From: from, To: to, Label: label}
+	if g.edges[e] {
+		return
+	}
+	g.edges[e] = true
+	g.Edges = append(g.Edges, e)
+}
@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+)
+
+var blocks = jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+
+func testIssue(key, issueType string, subtask bool) jira.Issue {
+	return jira.Issue{Key: key, Fields: jira.IssueFields{
+		Summary:   "Issue " + key,
+		Status:    &jira.Status{Name: "Open", Category: &jira.StatusCategory{Key: "new"}},
+		IssueType: &jira.IssueType{Name: issueType, Subtask: subtask},
+	}}
+}
+
+// testIssues returns a small project: epic P-1 holds story P-2, which
+// blocks P-3 and has subtask P-4; P-3 relates to P-5. Story C-2 has epic
+// C-1 as its parent field, as on Cloud, and epic I-2 has initiative I-1.
+func testIssues() map[string]*jira.Issue {
+	issues := map[string]*jira.Issue{}
+	for _, i := range []jira.Issue{
+		testIssue("P-1", "Epic", false),
+		testIssue("P-2", "Story", false),
+		testIssue("P-3", "Story", false),
+		testIssue("P-4", "Sub-task", true),
+		testIssue("P-5", "Story", false),
+		testIssue("C-1", "Epic", false),
+		testIssue("C-2", "Story", false),
+		testIssue("I-1", "Initiative", false),
+		testIssue("I-2", "Epic", false),
+	} {
+		issues[i.Key] = &i
+	}
+	relates := jira.IssueLinkType{Name: "Relates", Inward: "relates to", Outward: "relates to"}
+
+	p2, p3, p4, p5 := issues["P-2"], issues["P-3"], issues["P-4"], issues["P-5"]
+	issues["P-1"].EpicChildren = []jira.Issue{{Key: "P-2", Fields: jira.IssueFields{Summary: p2.Fields.Summary}}}
+	p2.Fields.EpicLink = "P-1"
+	p2.Fields.IssueLinks = []jira.IssueLink{{Type: blocks, OutwardIssue: &jira.Issue{Key: "P-3", Fields: p3.Fields}}}
+	p2.Fields.Subtasks = []jira.Issue{{Key: "P-4", Fields: jira.IssueFields{Summary: p4.Fields.Summary}}}
+	p3.Fields.IssueLinks = []jira.IssueLink{
+		{Type: blocks, InwardIssue: &jira.Issue{Key: "P-2", Fields: p2.Fields}},
+		{Type: relates, OutwardIssue: &jira.Issue{Key: "P-5"}},
+	}
+	p4.Fields.Parent = &jira.Issue{Key: "P-2", Fields: jira.IssueFields{IssueType: p2.Fields.IssueType}}
+	p5.Fields.IssueLinks = []jira.IssueLink{{Type: relates, InwardIssue: &jira.Issue{Key: "P-3"}}}
+	issues["C-2"].Fields.Parent = &jira.Issue{Key: "C-1", Fields: jira.IssueFields{IssueType: issues["C-1"].Fields.IssueType}}
+	issues["I-2"].Fields.Parent = &jira.Issue{Key: "I-1", Fields: jira.IssueFields{IssueType: issues["I-1"].Fields.IssueType}}
+	return issues
+}
+
+func TestCrawl(t *testing.T) {
+	tests := []struct {
+		name        string
+		roots       []string
+		depth       int
+		wantFetched []string
+		wantNodes   []string
+		wantEdges   []Edge
+	}{
+		{"depth 0 keeps neighbours as nodes", []string{"P-2"}, 0,
+			[]string{"P-2"},
+			[]string{"P-2", "P-3", "P-4", "P-1"},
+			[]Edge{{"P-2", "P-3", "blocks"}, {"P-2", "P-4", "subtask"}, {"P-1", "P-2", "epic"}}},
+		{"depth 1 de-duplicates edges seen from both ends", []string{"P-2"}, 1,
+			[]string{"P-2", "P-3", "P-4", "P-1"},
+			[]string{"P-2", "P-3", "P-4", "P-1", "P-5"},
+			[]Edge{{"P-2", "P-3", "blocks"}, {"P-2", "P-4", "subtask"}, {"P-1", "P-2", "epic"}, {"P-3", "P-5", "relates to"}}},
+		{"depth 2 reaches the far end", []string{"P-2"}, 2,
+			[]string{"P-2", "P-3", "P-4", "P-1", "P-5"},
+			[]string{"P-2", "P-3", "P-4", "P-1", "P-5"},
+			[]Edge{{"P-2", "P-3", "blocks"}, {"P-2", "P-4", "subtask"}, {"P-1", "P-2", "epic"}, {"P-3", "P-5", "relates to"}}},
+		{"repeated and related roots are fetched once", []string{"P-4", "P-4", "P-2"}, 1,
+			[]string{"P-4", "P-2", "P-3", "P-1"},
+			[]string{"P-4", "P-2", "P-3", "P-1", "P-5"},
+			[]Edge{{"P-2", "P-4", "subtask"}, {"P-2", "P-3", "blocks"}, {"P-1", "P-2", "epic"}, {"P-3", "P-5", "relates to"}}},
+		{"epic children when fetched", []string{"P-1"}, 0,
+			[]string{"P-1"},
+			[]string{"P-1", "P-2"},
+			[]Edge{{"P-1", "P-2", "epic"}}},
+		{"epic parent", []string{"C-2"}, 0,
+			[]string{"C-2"},
+			[]string{"C-2", "C-1"},
+			[]Edge{{"C-1", "C-2", "epic"}}},
+		{"other parent levels", []string{"I-2"}, 0,
+			[]string{"I-2"},
+			[]string{"I-2", "I-1"},
+			[]Edge{{"I-1", "I-2", "parent"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := testIssues()
+			var fetched []string
+			fetch := func(ctx context.Context, key string) (*jira.Issue, error) {
+				fetched = append(fetched, key)
+				issue, ok := issues[key]
+				if !ok {
+					return nil, fmt.Errorf("unexpected fetch of %s", key)
+				}
+				return issue, nil
+			}
+
+			g, err := Crawl(context.Background(), fetch, tt.roots, tt.depth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var nodes []string
+			for _, n := range g.Nodes {
+				nodes = append(nodes, n.Key)
+			}
+			if !reflect.DeepEqual(fetched, tt.wantFetched) {
+				t.Errorf("fetched %v, want %v", fetched, tt.wantFetched)
+			}
+			if !reflect.DeepEqual(nodes, tt.wantNodes) {
+				t.Errorf("nodes %v, want %v", nodes, tt.wantNodes)
+			}
+			if !reflect.DeepEqual(g.Edges, tt.wantEdges) {
+				t.Errorf("edges %v, want %v", g.Edges, tt.wantEdges)
+			}
+		})
+	}
+}
+
+func TestCrawlFetchError(t *testing.T) {
+	fetch := func(ctx context.Context, key string) (*jira.Issue, error) {
+		return nil, fmt.Errorf("issue %s does not exist", key)
+	}
+	if _, err := Crawl(context.Background(), fetch, []string{"P-9"}, 1); err == nil {
+		t.Error("Crawl succeeded, want the fetch error")
+	}
+}
+
+// TestAddIssueKeepsFetchedNodes checks that partial data embedded in a
+// neighbour never replaces a fetched issue.
+func TestAddIssueKeepsFetchedNodes(t *testing.T) {
+	g := New()
+	p3 := testIssue("P-3", "Story", false)
+	p3.Fields.Status = &jira.Status{Name: "In Progress", Category: &jira.StatusCategory{Key: "indeterminate"}}
+	g.AddIssue(&p3)
+
+	p2 := testIssue("P-2", "Story", false)
+	p2.Fields.IssueLinks = []jira.IssueLink{{Type: blocks, OutwardIssue: &jira.Issue{Key: "P-3", Fields: jira.IssueFields{Summary: "stale"}}}}
+	g.AddIssue(&p2)
+
+	want := Node{Key: "P-3", Summary: "Issue P-3", Status: "In Progress", Category: "indeterminate"}
+	if g.Nodes[0] != want {
+		t.Errorf("node %+v, want %+v", g.Nodes[0], want)
+	}
+}
@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// categoryColors are fill and border colors per status category, matching
+// Jira's status lozenges.
+var categoryColors = map[string][2]string{
+	"new":           {"#dfe1e6", "#42526e"},
+	"indeterminate": {"#deebff", "#0052cc"},
+	"done":          {"#e3fcef", "#006644"},
+}
+
+// maxSummary is the number of summary characters shown in a node label.
+const maxSummary = 40
+
+// Write renders the graph in the given format: dot, mermaid, markdown (a
+// fenced Mermaid block), json, or text (DOT, the plain-text form).
+func Write(w io.Writer, g *Graph, format string) error {
+	switch format {
+	case "dot", "text":
+		return WriteDOT(w, g)
+	case "mermaid":
+		return WriteMermaid(w, g)
+	case "markdown", "md":
+		if _, err := io.WriteString(w, "```mermaid\n"); err != nil {
+			return err
+		}
+		if err := WriteMermaid(w, g); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "```\n")
+		return err
+	case "json":
+		return WriteJSON(w, g)
+	default:
+		return fmt.Errorf("unknown graph format: %q (use dot, mermaid, markdown, json or text)", format)
+	}
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
+
+// WriteDOT renders the graph in Graphviz DOT format.
+func WriteDOT(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph jira {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\", fillcolor=\"#ffffff\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n\n")
+
+	for _, n := range g.Nodes {
+		label := n.Key
+		if n.Summary != "" {
+			label += "\n" + truncate(n.Summary, maxSummary)
+		}
+		if n.Status != "" {
+			label += "\n[" + n.Status + "]"
+		}
+		attrs := fmt.Sprintf("label=%s", dotQuote(label))
+		if c, ok := categoryColors[n.Category]; ok {
+			attrs += fmt.Sprintf(", fillcolor=%q, color=%q", c[0], c[1])
+		}
+		b.WriteString(fmt.Sprintf("  %s [%s];\n", dotQuote(n.Key), attrs))
+	}
+	if len(g.Edges) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range g.Edges {
+		b.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Label)))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote quotes s as a DOT string, keeping newlines as line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// mermaidID turns an issue key into a Mermaid node ID.
+func mermaidID(key string) string {
+	return mermaidUnsafe.ReplaceAllString(key, "_")
+}
+
+// mermaidText escapes s for use inside a quoted Mermaid label.
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "|", "#124;").Replace(s)
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart.
+func WriteMermaid(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+
+	for _, n := range g.Nodes {
+		label := mermaidText(n.Key)
+		if n.Summary != "" {
+			label += ": " + mermaidText(truncate(n.Summary, maxSummary))
+		}
+		if n.Status != "" {
+			label += "<br/>" + mermaidText(n.Status)
+		}
+		line := fmt.Sprintf("  %s[\"%s\"]", mermaidID(n.Key), label)
+		if _, ok := categoryColors[n.Category]; ok {
+			line += ":::" + n.Category
+		}
+		b.WriteString(line + "\n")
+	}
+	for _, e := range g.Edges {
+		b.WriteString(fmt.Sprintf("  %s -->|\"%s\"| %s\n", mermaidID(e.From), mermaidText(e.Label), mermaidID(e.To)))
+	}
+	for _, category := range []string{"new", "indeterminate", "done"} {
+		c := categoryColors[category]
+		b.WriteString(fmt.Sprintf("  classDef %s fill:%s,stroke:%s\n", category, c[0], c[1]))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Category string `json:"statusCategory,omitempty"`
+}
+
+type jsonEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label"`
+}
+
+// WriteJSON renders the graph as lists of nodes and edges.
+func WriteJSON(w io.Writer, g *Graph) error {
+	jg := jsonGraph{Nodes: []jsonNode{}, Edges: []jsonEdge{}}
+	for _, n := range g.Nodes {
+		jg.Nodes = append(jg.Nodes, jsonNode{Key: n.Key, Summary: n.Summary, Status: n.Status, Category: n.Category})
+	}
+	for _, e := range g.Edges {
+		jg.Edges = append(jg.Edges, jsonEdge{From: e.From, To: e.To, Label: e.Label})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jg)
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+)
+
+// renderGraph has a fetched node whose summary needs escaping and
+// truncating, and a bare node known only from a link.
+func renderGraph() *Graph {
+	g := New()
+	g.AddIssue(&jira.Issue{Key: "MUP-1", Fields: jira.IssueFields{
+		Summary:    `Say "hi" <b> to the | pipe and a very long summary`,
+		Status:     &jira.Status{Name: "In Progress", Category: &jira.StatusCategory{Key: "indeterminate"}},
+		IssueLinks: []jira.IssueLink{{Type: blocks, OutwardIssue: &jira.Issue{Key: "OPS-2"}}},
+	}})
+	return g
+}
+
+const wantDOT = `digraph jira {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fontname="Helvetica", fillcolor="#ffffff"];
+  edge [fontname="Helvetica", fontsize=10];
+
+  "MUP-1" [label="MUP-1\nSay \"hi\" <b> to the | pipe and a very l…\n[In Progress]", fillcolor="#deebff", color="#0052cc"];
+  "OPS-2" [label="OPS-2"];
+
+  "MUP-1" -> "OPS-2" [label="blocks"];
+}
+`
+
+const wantMermaid = `graph LR
+  MUP_1["MUP-1: Say #quot;hi#quot; #lt;b#gt; to the #124; pipe and a very l…<br/>In Progress"]:::indeterminate
+  OPS_2["OPS-2"]
+  MUP_1 -->|"blocks"| OPS_2
+  classDef new fill:#dfe1e6,stroke:#42526e
+  classDef indeterminate fill:#deebff,stroke:#0052cc
+  classDef done fill:#e3fcef,stroke:#006644
+`
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"dot", wantDOT},
+		{"text", wantDOT},
+		{"mermaid", wantMermaid},
+		{"markdown", "```mermaid\n" + wantMermaid + "```\n"},
+		{"json", `{
+  "nodes": [
+    {
+      "key": "MUP-1",
+      "summary": "Say \"hi\" \u003cb\u003e to the | pipe and a very long summary",
+      "status": "In Progress",
+      "statusCategory": "indeterminate"
+    },
+    {
+      "key": "OPS-2"
+    }
+  ],
+  "edges": [
+    {
+      "from": "MUP-1",
+      "to": "OPS-2",
+      "label": "blocks"
+    }
+  ]
+}
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b strings.Builder
+			if err := Write(&b, renderGraph(), tt.format); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Write(%s)\n got: %s\nwant: %s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	var b strings.Builder
+	if err := WriteDOT(&b, New()); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); strings.Contains(got, "->") || !strings.HasSuffix(got, "edge [fontname=\"Helvetica\", fontsize=10];\n\n}\n") {
+		t.Errorf("WriteDOT of an empty graph:\n%s", got)
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&strings.Builder{}, New(), "svg"); err == nil {
+		t.Error("Write(svg) succeeded, want an error")
+	}
+}
@@ -0,0 +1,146 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// textMimeTypes are non-text/* MIME types whose content is text.
+var textMimeTypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/yaml":       true,
+	"application/x-yaml":     true,
+	"application/x-sh":       true,
+	"application/javascript": true,
+	"application/sql":        true,
+}
+
+// textExtensions are file extensions treated as text whatever their MIME
+// type, since Jira often stores logs as application/octet-stream.
+var textExtensions = map[string]bool{
+	".txt": true, ".log": true, ".out": true, ".err": true, ".trace": true,
+	".json": true, ".yaml": true, ".yml": true, ".xml": true, ".csv": true,
+	".md": true, ".properties": true, ".conf": true, ".ini": true, ".toml": true,
+	".sql": true, ".sh": true, ".diff": true, ".patch": true,
+}
+
+// IsText reports whether the attachment looks like text by MIME type or extension.
+func (a Attachment) IsText() bool {
+	mimeType, _, _ := strings.Cut(a.MimeType, ";")
+	mimeType = strings.TrimSpace(strings.ToLower(mimeType))
+	if strings.HasPrefix(mimeType, "text/") || textMimeTypes[mimeType] {
+		return true
+	}
+	return textExtensions[strings.ToLower(filepath.Ext(a.Filename))]
+}
+
+// LoadAttachmentText downloads the text attachments on issue that are no
+// larger than maxSize bytes and stores their content in Attachment.Text.
+// Attachments whose content turns out not to be UTF-8 are left out.
+func (c *Client) LoadAttachmentText(ctx context.Context, issue *Issue, maxSize int64) error {
+	for i := range issue.Fields.Attachments {
+		a := &issue.Fields.Attachments[i]
+		if !a.IsText() || a.Size > maxSize {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := c.DownloadAttachment(ctx, *a, &buf); err != nil {
+			return fmt.Errorf("downloading %s: %w", a.Filename, err)
+		}
+		if !utf8.Valid(buf.Bytes()) {
+			continue
+		}
+		text := buf.String()
+		a.Text = &text
+	}
+	return nil
+}
+
+// GetAttachments returns the attachments on an issue.
+func (c *Client) GetAttachments(ctx context.Context, key string) ([]Attachment, error) {
+	var issue Issue
+	path := fmt.Sprintf("/rest/api/2/issue/%s?fields=attachment", url.PathEscape(key))
+	if err := c.do(ctx, "GET", path, &issue); err != nil {
+		return nil, err
+	}
+	return issue.Fields.Attachments, nil
+}
+
+// AddAttachments uploads files to an issue and returns the created attachments.
+func (c *Client) AddAttachments(ctx context.Context, key string, files []string) ([]Attachment, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, name := range files {
+		if err := addFilePart(mw, name); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("encoding attachments: %w", err)
+	}
+
+	header := http.Header{
+		"Content-Type": {mw.FormDataContentType()},
+		// Jira rejects multipart uploads without this XSRF opt-out.
+		"X-Atlassian-Token": {"no-check"},
+	}
+	var attachments []Attachment
+	path := fmt.Sprintf("/rest/api/2/issue/%s/attachments", url.PathEscape(key))
+	if err := c.doRaw(ctx, "POST", path, body.Bytes(), header, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func addFilePart(mw *multipart.Writer, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	part, err := mw.CreateFormFile("file", filepath.Base(name))
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	return nil
+}
+
+// DownloadAttachment writes the content of an attachment to w.
+func (c *Client) DownloadAttachment(ctx context.Context, a Attachment, w io.Writer) error {
+	req, err := c.newRequest(ctx, "GET", a.Content, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "<<< HTTP %d (%s, %d bytes)\n", resp.StatusCode, resp.Header.Get("Content-Type"), resp.ContentLength)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("downloading %s: %w", a.Filename, err)
+	}
+	return nil
+}
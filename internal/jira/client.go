@@ -2,6 +2,7 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,50 +16,152 @@ import (
 // Client is an authenticated Jira REST API client.
 type Client struct {
 	baseURL    string
-	token      string
+	auth       Authenticator
 	verbose    bool
 	httpClient *http.Client
+	retry      RetryPolicy
+
+	epicChildren string
+
+	// deployment is DeploymentAuto, DeploymentServer or DeploymentCloud;
+	// cloud caches the result of detecting it.
+	deployment string
+	cloud      *bool
+
+	cacheDir       string
+	fieldOverrides map[string]string
+	fields         []Field
+	fieldMap       FieldMap
+}
+
+// Option configures optional Client behaviour.
+type Option func(*Client)
+
+// WithRetryPolicy sets the policy used to retry throttled or failed requests.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithRequestTimeout sets the timeout for a single HTTP round trip. Zero disables it,
+// leaving cancellation to the context passed to each method.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
 }
 
-// NewClient creates a new Jira API client.
-func NewClient(baseURL, token string, verbose bool) *Client {
-	return &Client{
+// NewClient creates a new Jira API client that authenticates requests with
+// auth. A nil auth sends requests without credentials.
+func NewClient(baseURL string, auth Authenticator, verbose bool, opts ...Option) *Client {
+	if auth == nil {
+		auth = noAuth{}
+	}
+	c := &Client{
 		baseURL: baseURL,
-		token:   token,
+		auth:    auth,
 		verbose: verbose,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		retry:        DefaultRetryPolicy,
+		epicChildren: EpicChildrenEpicLink,
+		deployment:   DeploymentAuto,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // do executes an authenticated HTTP request and decodes the JSON response.
-func (c *Client) do(method, path string, result interface{}) error {
-	return c.doWithBody(method, path, nil, result)
+func (c *Client) do(ctx context.Context, method, path string, result interface{}) error {
+	return c.doWithBody(ctx, method, path, nil, result)
 }
 
 // doWithBody executes an authenticated HTTP request with a JSON body and decodes the JSON response.
-func (c *Client) doWithBody(method, path string, body interface{}, result interface{}) error {
-	reqURL := c.baseURL + path
-
-	var reqBody io.Reader
+func (c *Client) doWithBody(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var (
+		data   []byte
+		header http.Header
+	)
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		data, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("encoding request body: %w", err)
 		}
-		reqBody = bytes.NewReader(jsonData)
+		header = http.Header{"Content-Type": {"application/json"}}
 	}
+	return c.doRaw(ctx, method, path, data, header, result)
+}
 
-	req, err := http.NewRequest(method, reqURL, reqBody)
+// doRaw executes an authenticated HTTP request with a pre-encoded body and extra headers,
+// and decodes the JSON response. Transport errors and transient HTTP statuses are retried
+// according to the client's RetryPolicy. REST API v2 paths are sent to v3 on Jira Cloud.
+func (c *Client) doRaw(ctx context.Context, method, path string, data []byte, reqHeader http.Header, result interface{}) error {
+	path, err := c.apiPath(ctx, path)
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return err
 	}
+	reqURL := c.baseURL + path
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/json")
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	var (
+		status   int
+		respBody []byte
+	)
+	for attempt := 1; ; attempt++ {
+		var header http.Header
+		status, header, respBody, err = c.send(ctx, method, reqURL, data, reqHeader)
+		if err == nil && !retryableStatus(status) {
+			break
+		}
+		if attempt >= c.retry.MaxAttempts || !c.retry.allows(method) || ctx.Err() != nil {
+			break
+		}
+
+		wait := c.retry.backoff(attempt)
+		if err == nil {
+			if d, ok := retryAfter(header); ok {
+				wait = d
+			}
+		}
+		if c.retry.MaxDelay > 0 && wait > c.retry.MaxDelay {
+			wait = c.retry.MaxDelay
+		}
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "    retrying in %s (attempt %d of %d)\n", wait.Round(time.Millisecond), attempt+1, c.retry.MaxAttempts)
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("API error (HTTP %d): %s", status, string(respBody))
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// newRequest creates an authenticated request and logs it when verbose.
+func (c *Client) newRequest(ctx context.Context, method, reqURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if err := c.auth.Authenticate(req); err != nil {
+		return nil, fmt.Errorf("authenticating request: %w", err)
 	}
 
 	if c.verbose {
@@ -71,39 +174,59 @@ func (c *Client) doWithBody(method, path string, body interface{}, result interf
 			}
 		}
 	}
+	return req, nil
+}
+
+// send performs a single HTTP round trip and returns the status, headers and body.
+func (c *Client) send(ctx context.Context, method, reqURL string, data []byte, header http.Header) (int, http.Header, []byte, error) {
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := c.newRequest(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, values := range header {
+		req.Header[name] = values
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return 0, nil, nil, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("reading response body: %w", err)
+		return 0, nil, nil, fmt.Errorf("reading response body: %w", err)
 	}
 
 	if c.verbose {
 		fmt.Fprintf(os.Stderr, "<<< HTTP %d\n%s\n", resp.StatusCode, string(respBody))
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(respBody))
-	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
 
-	if result != nil {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("decoding response: %w", err)
-		}
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-
-	return nil
 }
 
 // Myself returns the currently authenticated user. Useful for testing auth.
-func (c *Client) Myself() (*User, error) {
+func (c *Client) Myself(ctx context.Context) (*User, error) {
 	var user User
-	if err := c.do("GET", "/rest/api/2/myself", &user); err != nil {
+	if err := c.do(ctx, "GET", "/rest/api/2/myself", &user); err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -0,0 +1,297 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/markup"
+)
+
+// Deployment types accepted by WithDeployment.
+const (
+	DeploymentAuto   = "auto"
+	DeploymentServer = "server"
+	DeploymentCloud  = "cloud"
+)
+
+// serverInfoPath is queried to detect the deployment type. It is never
+// rewritten to REST API v3, since detection decides whether to do that.
+const serverInfoPath = "/rest/api/2/serverInfo"
+
+// WithDeployment sets whether the Jira instance is Server/Data Center or
+// Cloud. With DeploymentAuto, the default, it is detected on first use.
+func WithDeployment(deployment string) Option {
+	return func(c *Client) {
+		c.deployment = deployment
+	}
+}
+
+// ValidDeployment checks a deployment type for WithDeployment.
+func ValidDeployment(deployment string) error {
+	switch deployment {
+	case DeploymentAuto, DeploymentServer, DeploymentCloud:
+		return nil
+	}
+	return fmt.Errorf("deployment must be auto, server or cloud, got %q", deployment)
+}
+
+// IsCloud reports whether the client talks to Jira Cloud. Unless set with
+// WithDeployment, this is decided by an atlassian.net host name or else the
+// deploymentType reported by serverInfo, and remembered for later calls.
+func (c *Client) IsCloud(ctx context.Context) (bool, error) {
+	switch c.deployment {
+	case DeploymentCloud:
+		return true, nil
+	case DeploymentServer:
+		return false, nil
+	}
+	if c.cloud != nil {
+		return *c.cloud, nil
+	}
+
+	var cloud bool
+	if IsCloudURL(c.baseURL) {
+		cloud = true
+	} else {
+		var info struct {
+			DeploymentType string `json:"deploymentType"`
+		}
+		if err := c.do(ctx, "GET", serverInfoPath, &info); err != nil {
+			return false, fmt.Errorf("detecting Jira deployment type: %w", err)
+		}
+		cloud = strings.EqualFold(info.DeploymentType, "Cloud")
+	}
+	c.cloud = &cloud
+	return cloud, nil
+}
+
+// IsCloudURL reports whether baseURL is on a Jira Cloud (atlassian.net) host.
+// Cloud sites on custom domains are only recognised by IsCloud.
+func IsCloudURL(baseURL string) bool {
+	u, err := neturl.Parse(baseURL)
+	return err == nil && strings.HasSuffix(strings.ToLower(u.Hostname()), ".atlassian.net")
+}
+
+// apiPath rewrites a REST API v2 path to v3 on Jira Cloud.
+func (c *Client) apiPath(ctx context.Context, path string) (string, error) {
+	if path == serverInfoPath || !strings.HasPrefix(path, "/rest/api/2/") {
+		return path, nil
+	}
+	cloud, err := c.IsCloud(ctx)
+	if err != nil || !cloud {
+		return path, err
+	}
+	return "/rest/api/3/" + strings.TrimPrefix(path, "/rest/api/2/"), nil
+}
+
+// searchCloud runs a JQL query against Jira Cloud's /search/jql, which pages
+// with tokens instead of startAt and reports no total. It fetches a single
+// page of up to limit issues, or with all set every issue (or limit if
+// limit > 0). When issues remain unfetched, Total is the approximate count.
+func (c *Client) searchCloud(ctx context.Context, jql string, limit int, all bool) (*SearchResult, error) {
+	result := &SearchResult{}
+	var token string
+	for {
+		pageSize := limit
+		if all {
+			pageSize = searchPageSize
+			if limit > 0 && limit-len(result.Issues) < pageSize {
+				pageSize = limit - len(result.Issues)
+			}
+		}
+
+		params := neturl.Values{}
+		params.Set("jql", jql)
+		params.Set("maxResults", strconv.Itoa(pageSize))
+		params.Set("fields", "*navigable")
+		if token != "" {
+			params.Set("nextPageToken", token)
+		}
+		var page struct {
+			Issues        []Issue `json:"issues"`
+			NextPageToken string  `json:"nextPageToken"`
+			IsLast        bool    `json:"isLast"`
+		}
+		if err := c.do(ctx, "GET", "/rest/api/3/search/jql?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, page.Issues...)
+		token = page.NextPageToken
+
+		if page.IsLast || token == "" || len(page.Issues) == 0 {
+			result.Total = len(result.Issues)
+			break
+		}
+		if !all || (limit > 0 && len(result.Issues) >= limit) {
+			total, err := c.approximateCount(ctx, jql)
+			if err != nil {
+				return nil, err
+			}
+			result.Total = max(total, len(result.Issues))
+			break
+		}
+	}
+
+	fm, err := c.FieldMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Issues {
+		fm.decodeCustomFields(&result.Issues[i].Fields)
+	}
+	result.MaxResults = len(result.Issues)
+	return result, nil
+}
+
+// approximateCount returns Jira Cloud's estimate of the number of issues
+// matching jql.
+func (c *Client) approximateCount(ctx context.Context, jql string) (int, error) {
+	var response struct {
+		Count int `json:"count"`
+	}
+	req := map[string]string{"jql": jql}
+	if err := c.doWithBody(ctx, "POST", "/rest/api/3/search/approximate-count", req, &response); err != nil {
+		return 0, fmt.Errorf("counting search results: %w", err)
+	}
+	return response.Count, nil
+}
+
+// wikiToADF converts Jira wiki markup to an ADF document, through Markdown.
+func wikiToADF(s string) *markup.ADFNode {
+	return markup.MarkdownToADF(markup.WikiToMarkdown(s))
+}
+
+// richTextADF converts rich text to an ADF document: Markdown directly when
+// isMarkdown is set, else wiki markup.
+func richTextADF(s string, isMarkdown bool) *markup.ADFNode {
+	if isMarkdown {
+		return markup.MarkdownToADF(s)
+	}
+	return wikiToADF(s)
+}
+
+// flattenADF replaces the ADF documents among the members of a JSON object
+// with their Markdown, so REST API v3 rich text decodes into the same string
+// fields as v2. It reports whether any member was converted.
+func flattenADF(data []byte) ([]byte, bool, error) {
+	if !bytes.Contains(data, []byte(`"doc"`)) {
+		return data, false, nil
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, false, err
+	}
+	changed := false
+	for name, raw := range members {
+		if len(raw) == 0 || raw[0] != '{' || !bytes.Contains(raw, []byte(`"doc"`)) {
+			continue
+		}
+		var doc markup.ADFNode
+		if err := json.Unmarshal(raw, &doc); err != nil || doc.Type != "doc" {
+			continue
+		}
+		md, err := json.Marshal(markup.ADFToMarkdown(&doc))
+		if err != nil {
+			return nil, false, err
+		}
+		members[name] = md
+		changed = true
+	}
+	if !changed {
+		return data, false, nil
+	}
+	data, err := json.Marshal(members)
+	return data, true, err
+}
+
+// richTextField reports whether the field with this ID takes an ADF
+// document on Jira Cloud.
+func richTextField(fields []Field, id string) bool {
+	if id == "description" || id == "environment" {
+		return true
+	}
+	for _, f := range fields {
+		if f.ID == id {
+			return strings.HasSuffix(f.Schema.Custom, ":textarea")
+		}
+	}
+	return false
+}
+
+// encodeRichText converts the values of rich text fields in values, and in
+// the set operations of update, from wiki markup, or Markdown if markdown is
+// set: to ADF documents on Cloud, to wiki markup on Server.
+func (c *Client) encodeRichText(ctx context.Context, values map[string]interface{}, update map[string][]map[string]interface{}, markdown bool) error {
+	cloud, err := c.IsCloud(ctx)
+	if err != nil || !cloud && !markdown {
+		return err
+	}
+	encode := func(s string) interface{} {
+		if cloud {
+			return richTextADF(s, markdown)
+		}
+		return markup.MarkdownToWiki(s)
+	}
+
+	fields, err := c.Fields(ctx)
+	if err != nil {
+		return err
+	}
+	for id, v := range values {
+		if s, ok := v.(string); ok && richTextField(fields, id) {
+			values[id] = encode(s)
+		}
+	}
+	for id, ops := range update {
+		if !richTextField(fields, id) {
+			continue
+		}
+		for _, op := range ops {
+			if s, ok := op["set"].(string); ok {
+				op["set"] = encode(s)
+			}
+		}
+	}
+	return nil
+}
+
+// commentPayload returns req as sent to Jira: with the body as wiki markup
+// for Server, as an ADF document for Cloud.
+func (c *Client) commentPayload(ctx context.Context, req *CommentRequest) (interface{}, error) {
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !cloud {
+		if req.Markdown {
+			wiki := *req
+			wiki.Body = markup.MarkdownToWiki(req.Body)
+			return &wiki, nil
+		}
+		return req, nil
+	}
+	return struct {
+		Body       *markup.ADFNode `json:"body"`
+		Visibility *Visibility     `json:"visibility,omitempty"`
+	}{richTextADF(req.Body, req.Markdown), req.Visibility}, nil
+}
+
+// worklogPayload returns req as sent to Jira: unchanged for Server, with the
+// comment as an ADF document for Cloud.
+func (c *Client) worklogPayload(ctx context.Context, req *WorklogRequest) (interface{}, error) {
+	cloud, err := c.IsCloud(ctx)
+	if err != nil || !cloud || req.Comment == "" {
+		return req, err
+	}
+	return struct {
+		Comment          *markup.ADFNode `json:"comment"`
+		Started          string          `json:"started"`
+		TimeSpentSeconds int             `json:"timeSpentSeconds"`
+		Visibility       *Visibility     `json:"visibility,omitempty"`
+	}{wikiToADF(req.Comment), req.Started, req.TimeSpentSeconds, req.Visibility}, nil
+}
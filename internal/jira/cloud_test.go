@@ -0,0 +1,30 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCommentUnmarshalADF(t *testing.T) {
+	data := `{"id":"1","body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[
+		{"type":"text","text":"Ask "},
+		{"type":"mention","attrs":{"id":"557058:def","text":"@Alice Smith"}},
+		{"type":"text","text":" about -v- and {x}"}]}]}}`
+	var c Comment
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Ask @Alice Smith about -v- and {x}"; c.Body != want || !c.Markdown {
+		t.Errorf("got body %q, markdown %v; want %q, true", c.Body, c.Markdown, want)
+	}
+}
+
+func TestIssueFieldsUnmarshalWiki(t *testing.T) {
+	var f IssueFields
+	if err := json.Unmarshal([]byte(`{"summary":"s","description":"h1. Wiki"}`), &f); err != nil {
+		t.Fatal(err)
+	}
+	if f.Description != "h1. Wiki" || f.Markdown {
+		t.Errorf("got description %q, markdown %v; want wiki markup kept", f.Description, f.Markdown)
+	}
+}
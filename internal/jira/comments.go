@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// CommentRequest is the payload for adding or editing a comment.
+type CommentRequest struct {
+	Body       string      `json:"body"`
+	Visibility *Visibility `json:"visibility,omitempty"`
+
+	// Markdown marks Body as Markdown rather than wiki markup. It is sent
+	// as ADF on Jira Cloud and as wiki markup on Server/Data Center.
+	Markdown bool `json:"-"`
+}
+
+// commentPageSize is the page size requested when paging through comments.
+const commentPageSize = 100
+
+// GetComments fetches one page of comments on an issue, oldest first.
+func (c *Client) GetComments(ctx context.Context, key string, startAt, maxResults int) (*Comments, error) {
+	var result Comments
+	params := url.Values{}
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	path := fmt.Sprintf("/rest/api/2/issue/%s/comment?%s", url.PathEscape(key), params.Encode())
+	if err := c.do(ctx, "GET", path, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAllComments pages through the comments on an issue until all, or limit
+// if limit > 0, have been fetched.
+func (c *Client) GetAllComments(ctx context.Context, key string, limit int) (*Comments, error) {
+	all := &Comments{}
+	for {
+		pageSize := commentPageSize
+		if limit > 0 && limit-len(all.Comments) < pageSize {
+			pageSize = limit - len(all.Comments)
+		}
+
+		page, err := c.GetComments(ctx, key, len(all.Comments), pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		all.Total = page.Total
+		all.Comments = append(all.Comments, page.Comments...)
+
+		if len(page.Comments) == 0 || len(all.Comments) >= page.Total {
+			break
+		}
+		if limit > 0 && len(all.Comments) >= limit {
+			break
+		}
+	}
+	all.MaxResults = len(all.Comments)
+	return all, nil
+}
+
+// GetComment fetches a single comment by ID.
+func (c *Client) GetComment(ctx context.Context, key, id string) (*Comment, error) {
+	var comment Comment
+	path := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", url.PathEscape(key), url.PathEscape(id))
+	if err := c.do(ctx, "GET", path, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// AddComment adds a comment to an issue and returns the created comment.
+func (c *Client) AddComment(ctx context.Context, key string, req *CommentRequest) (*Comment, error) {
+	var comment Comment
+	path := fmt.Sprintf("/rest/api/2/issue/%s/comment", url.PathEscape(key))
+	body, err := c.commentPayload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.doWithBody(ctx, "POST", path, body, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateComment replaces the body (and visibility) of an existing comment.
+func (c *Client) UpdateComment(ctx context.Context, key, id string, req *CommentRequest) (*Comment, error) {
+	var comment Comment
+	path := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", url.PathEscape(key), url.PathEscape(id))
+	body, err := c.commentPayload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.doWithBody(ctx, "PUT", path, body, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// DeleteComment removes a comment from an issue.
+func (c *Client) DeleteComment(ctx context.Context, key, id string) error {
+	path := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", url.PathEscape(key), url.PathEscape(id))
+	return c.do(ctx, "DELETE", path, nil)
+}
@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Strategies for finding the issues in an epic, accepted by WithEpicChildren.
+// Any other value is a JQL template in which {key} is replaced by the epic key.
+const (
+	// EpicChildrenEpicLink queries the Epic Link field (company-managed
+	// projects on Server/Data Center), falling back to EpicChildrenParent
+	// on instances without one.
+	EpicChildrenEpicLink = "epic-link"
+	// EpicChildrenParent queries "parent = KEY", as used by Jira Cloud and
+	// team-managed projects.
+	EpicChildrenParent = "parent"
+	// EpicChildrenNone disables fetching epic children in GetIssue.
+	EpicChildrenNone = "none"
+)
+
+// epicKeyPlaceholder is replaced by the epic key in a child JQL template.
+const epicKeyPlaceholder = "{key}"
+
+// epicChildrenOrder is appended to the built-in child queries.
+const epicChildrenOrder = " ORDER BY status ASC, key ASC"
+
+// WithEpicChildren sets how GetIssue finds the issues in an epic: one of the
+// EpicChildren* strategies or a JQL template containing {key}. The default is
+// EpicChildrenEpicLink.
+func WithEpicChildren(strategy string) Option {
+	return func(c *Client) {
+		if strategy != "" {
+			c.epicChildren = strategy
+		}
+	}
+}
+
+// ValidEpicChildren reports whether s is a strategy or template accepted by
+// WithEpicChildren.
+func ValidEpicChildren(s string) error {
+	switch s {
+	case EpicChildrenEpicLink, EpicChildrenParent, EpicChildrenNone:
+		return nil
+	}
+	if !strings.Contains(s, epicKeyPlaceholder) {
+		return fmt.Errorf("epic children must be %s, %s, %s or a JQL template containing %s, got %q",
+			EpicChildrenEpicLink, EpicChildrenParent, EpicChildrenNone, epicKeyPlaceholder, s)
+	}
+	return nil
+}
+
+// EpicChildrenJQL returns the JQL query for the issues in epic key, or "" if
+// fetching epic children is disabled.
+func (c *Client) EpicChildrenJQL(ctx context.Context, key string) (string, error) {
+	switch c.epicChildren {
+	case EpicChildrenNone:
+		return "", nil
+	case EpicChildrenParent:
+		return fmt.Sprintf("parent = %s", key) + epicChildrenOrder, nil
+	case EpicChildrenEpicLink:
+		fm, err := c.FieldMap(ctx)
+		if err != nil {
+			return "", err
+		}
+		field, err := fm.JQLName(FieldEpicLink)
+		if err != nil {
+			return fmt.Sprintf("parent = %s", key) + epicChildrenOrder, nil
+		}
+		return fmt.Sprintf("%s = %s", field, key) + epicChildrenOrder, nil
+	default:
+		return strings.ReplaceAll(c.epicChildren, epicKeyPlaceholder, key), nil
+	}
+}
+
+// GetEpicChildren fetches every issue in epic key, following search pages.
+// It returns nil if fetching epic children is disabled.
+func (c *Client) GetEpicChildren(ctx context.Context, key string) ([]Issue, error) {
+	jql, err := c.EpicChildrenJQL(ctx, key)
+	if err != nil || jql == "" {
+		return nil, err
+	}
+	result, err := c.SearchAll(ctx, jql, 0)
+	if err != nil {
+		return nil, fmt.Errorf("fetching issues in epic %s: %w", key, err)
+	}
+	return result.Issues, nil
+}
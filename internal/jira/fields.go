@@ -0,0 +1,335 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Field describes a Jira field as returned by /rest/api/2/field.
+type Field struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Custom bool        `json:"custom"`
+	Schema FieldSchema `json:"schema"`
+}
+
+// FieldSchema describes the value type of a field.
+type FieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items,omitempty"`
+	System string `json:"system,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// Logical names of the custom fields the client decodes into IssueFields and
+// encodes from the create/update requests. They are also the keys accepted
+// by WithFieldOverrides.
+const (
+	FieldEpicLink    = "epic-link"
+	FieldEpicName    = "epic-name"
+	FieldParentLink  = "parent-link"
+	FieldStoryPoints = "story-points"
+	FieldSprint      = "sprint"
+)
+
+// knownFields lists how each logical field is recognised: by its custom field
+// type when the plugin defines one, otherwise by its display name.
+var knownFields = []struct {
+	key        string
+	names      []string
+	customType string
+}{
+	{FieldEpicLink, []string{"Epic Link"}, "com.pyxis.greenhopper.jira:gh-epic-link"},
+	{FieldEpicName, []string{"Epic Name"}, "com.pyxis.greenhopper.jira:gh-epic-label"},
+	{FieldParentLink, []string{"Parent Link"}, "com.atlassian.jpo:jpo-custom-field-parent"},
+	{FieldStoryPoints, []string{"Story Points", "Story point estimate"}, ""},
+	{FieldSprint, []string{"Sprint"}, "com.pyxis.greenhopper.jira:gh-sprint"},
+}
+
+// fieldCacheTTL is how long the field list from /rest/api/2/field is reused.
+const fieldCacheTTL = 24 * time.Hour
+
+// FieldMap maps logical field names (FieldEpicLink, ...) to field IDs.
+type FieldMap map[string]string
+
+// WithFieldOverrides pins logical field names to field IDs, bypassing discovery
+// for those fields.
+func WithFieldOverrides(overrides map[string]string) Option {
+	return func(c *Client) {
+		c.fieldOverrides = overrides
+	}
+}
+
+// WithCacheDir sets the directory used to cache field metadata per instance.
+// Without it, field metadata is fetched once per client.
+func WithCacheDir(dir string) Option {
+	return func(c *Client) {
+		c.cacheDir = dir
+	}
+}
+
+// Fields returns all fields defined on the instance, using the on-disk cache
+// when it is fresh.
+func (c *Client) Fields(ctx context.Context) ([]Field, error) {
+	if c.fields != nil {
+		return c.fields, nil
+	}
+
+	cachePath := c.fieldCachePath()
+	if cachePath != "" {
+		if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < fieldCacheTTL {
+			if data, err := os.ReadFile(cachePath); err == nil {
+				var fields []Field
+				if json.Unmarshal(data, &fields) == nil {
+					c.fields = fields
+					return fields, nil
+				}
+			}
+		}
+	}
+
+	var fields []Field
+	if err := c.do(ctx, "GET", "/rest/api/2/field", &fields); err != nil {
+		return nil, fmt.Errorf("fetching field metadata: %w", err)
+	}
+	c.fields = fields
+
+	if cachePath != "" {
+		// The cache is an optimisation; failing to write it is not an error.
+		if data, err := json.Marshal(fields); err == nil {
+			if os.MkdirAll(filepath.Dir(cachePath), 0o700) == nil {
+				_ = os.WriteFile(cachePath, data, 0o600)
+			}
+		}
+	}
+	return fields, nil
+}
+
+// FieldMap resolves the logical custom fields to field IDs on this instance.
+// Fields that do not exist are absent from the map.
+func (c *Client) FieldMap(ctx context.Context) (FieldMap, error) {
+	if c.fieldMap != nil {
+		return c.fieldMap, nil
+	}
+
+	fm := FieldMap{}
+	needDiscovery := false
+	for _, kf := range knownFields {
+		if id := c.fieldOverrides[kf.key]; id != "" {
+			fm[kf.key] = id
+		} else {
+			needDiscovery = true
+		}
+	}
+
+	if needDiscovery {
+		fields, err := c.Fields(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, kf := range knownFields {
+			if _, ok := fm[kf.key]; ok {
+				continue
+			}
+			if id := findField(fields, kf.customType, kf.names); id != "" {
+				fm[kf.key] = id
+			}
+		}
+	}
+
+	c.fieldMap = fm
+	return fm, nil
+}
+
+// findField returns the ID of the custom field with the given custom type,
+// falling back to a case-insensitive match on one of names.
+func findField(fields []Field, customType string, names []string) string {
+	if customType != "" {
+		for _, f := range fields {
+			if f.Schema.Custom == customType {
+				return f.ID
+			}
+		}
+	}
+	for _, name := range names {
+		for _, f := range fields {
+			if f.Custom && strings.EqualFold(f.Name, name) {
+				return f.ID
+			}
+		}
+	}
+	return ""
+}
+
+// fieldCachePath returns the cache file for this instance, or "" if caching is off.
+func (c *Client) fieldCachePath() string {
+	if c.cacheDir == "" {
+		return ""
+	}
+	name := c.baseURL
+	if u, err := neturl.Parse(c.baseURL); err == nil && u.Host != "" {
+		name = u.Host + u.Path
+	}
+	name = unsafeFileChars.ReplaceAllString(strings.Trim(name, "/"), "_")
+	return filepath.Join(c.cacheDir, "fields-"+name+".json")
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// ID returns the field ID for key, or an error explaining how to configure it.
+func (fm FieldMap) ID(key string) (string, error) {
+	if id := fm[key]; id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("no %q field found on this Jira instance (set fields.%s in your profile)", key, key)
+}
+
+// JQLName returns how JQL refers to the field behind key, e.g. "cf[10761]",
+// which unlike the field name does not depend on the instance's language.
+func (fm FieldMap) JQLName(key string) (string, error) {
+	id, err := fm.ID(key)
+	if err != nil {
+		return "", err
+	}
+	if n, ok := strings.CutPrefix(id, "customfield_"); ok {
+		return "cf[" + n + "]", nil
+	}
+	return id, nil
+}
+
+// decodeCustomFields fills the custom-field-backed members of IssueFields
+// from the raw field values.
+func (fm FieldMap) decodeCustomFields(f *IssueFields) {
+	if f.Raw == nil {
+		return
+	}
+	if raw, ok := f.Raw[fm[FieldEpicLink]]; ok {
+		_ = json.Unmarshal(raw, &f.EpicLink)
+	}
+	if raw, ok := f.Raw[fm[FieldParentLink]]; ok {
+		_ = json.Unmarshal(raw, &f.ParentLink)
+	}
+	if raw, ok := f.Raw[fm[FieldStoryPoints]]; ok {
+		_ = json.Unmarshal(raw, &f.StoryPoints)
+	}
+	if raw, ok := f.Raw[fm[FieldSprint]]; ok {
+		f.Sprints = decodeSprints(raw)
+	}
+}
+
+// encodeCreateFields moves the custom-field-backed members of f into f.Custom
+// under their instance-specific field IDs.
+func (fm FieldMap) encodeCreateFields(f *IssueCreateFields) error {
+	values := map[string]interface{}{}
+	if f.EpicLink != "" {
+		values[FieldEpicLink] = f.EpicLink
+	}
+	if f.EpicName != "" {
+		values[FieldEpicName] = f.EpicName
+	}
+	if f.ParentLink != "" {
+		values[FieldParentLink] = f.ParentLink
+	}
+	if f.StoryPoints != nil {
+		values[FieldStoryPoints] = *f.StoryPoints
+	}
+	return fm.encode(values, &f.Custom)
+}
+
+// encodeUpdateFields is the IssueUpdateFields counterpart of encodeCreateFields.
+func (fm FieldMap) encodeUpdateFields(f *IssueUpdateFields) error {
+	values := map[string]interface{}{}
+	if f.EpicLink != nil {
+		values[FieldEpicLink] = *f.EpicLink
+	}
+	if f.EpicName != nil {
+		values[FieldEpicName] = *f.EpicName
+	}
+	if f.ParentLink != nil {
+		values[FieldParentLink] = *f.ParentLink
+	}
+	if f.StoryPoints != nil {
+		values[FieldStoryPoints] = *f.StoryPoints
+	}
+	return fm.encode(values, &f.Custom)
+}
+
+// encode stores values, keyed by logical field name, into custom keyed by field ID.
+func (fm FieldMap) encode(values map[string]interface{}, custom *map[string]interface{}) error {
+	for key, value := range values {
+		id, err := fm.ID(key)
+		if err != nil {
+			return err
+		}
+		if *custom == nil {
+			*custom = map[string]interface{}{}
+		}
+		(*custom)[id] = value
+	}
+	return nil
+}
+
+// hasCustomCreateFields reports whether f sets any custom-field-backed member.
+func hasCustomCreateFields(f *IssueCreateFields) bool {
+	return f.EpicLink != "" || f.EpicName != "" || f.ParentLink != "" || f.StoryPoints != nil
+}
+
+// hasCustomUpdateFields reports whether f sets any custom-field-backed member.
+func hasCustomUpdateFields(f *IssueUpdateFields) bool {
+	return f.EpicLink != nil || f.EpicName != nil || f.ParentLink != nil || f.StoryPoints != nil
+}
+
+// mergeCustom adds custom field values to the JSON object in data.
+func mergeCustom(data []byte, custom map[string]interface{}) ([]byte, error) {
+	if len(custom) == 0 {
+		return data, nil
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for id, value := range custom {
+		merged[id] = value
+	}
+	return json.Marshal(merged)
+}
+
+// sprintName extracts name=... from the legacy Jira Server sprint string
+// "com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,state=ACTIVE,name=Sprint 5,...]".
+var sprintName = regexp.MustCompile(`[\[,]name=([^,\]]*)`)
+
+// decodeSprints returns sprint names from either the Server string format or
+// the object format used by newer versions and Cloud.
+func decodeSprints(raw json.RawMessage) []string {
+	var objects []struct {
+		Name string `json:"name"`
+	}
+	if json.Unmarshal(raw, &objects) == nil {
+		var names []string
+		for _, o := range objects {
+			if o.Name != "" {
+				names = append(names, o.Name)
+			}
+		}
+		return names
+	}
+
+	var legacy []string
+	if json.Unmarshal(raw, &legacy) != nil {
+		return nil
+	}
+	var names []string
+	for _, s := range legacy {
+		if m := sprintName.FindStringSubmatch(s); m != nil {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
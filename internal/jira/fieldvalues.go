@@ -0,0 +1,198 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldValue is the raw value of a field on an issue, paired with its metadata.
+type FieldValue struct {
+	Field Field
+	Raw   json.RawMessage
+}
+
+// FindField looks up a field by ID (e.g. "customfield_10761") or by display
+// name (case-insensitive). Ambiguous names are reported with their IDs.
+func (c *Client) FindField(ctx context.Context, nameOrID string) (Field, error) {
+	fields, err := c.Fields(ctx)
+	if err != nil {
+		return Field{}, err
+	}
+	for _, f := range fields {
+		if f.ID == nameOrID {
+			return f, nil
+		}
+	}
+	var matches []Field
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, nameOrID) {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Field{}, fmt.Errorf("unknown field %q", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, f := range matches {
+			ids[i] = f.ID
+		}
+		return Field{}, fmt.Errorf("field name %q is ambiguous, use one of the IDs: %s", nameOrID, strings.Join(ids, ", "))
+	}
+}
+
+// FindFields resolves a list of field names or IDs with FindField.
+func (c *Client) FindFields(ctx context.Context, namesOrIDs []string) ([]Field, error) {
+	fields := make([]Field, 0, len(namesOrIDs))
+	for _, name := range namesOrIDs {
+		f, err := c.FindField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// ConvertFieldValues resolves user-friendly field names or IDs to field IDs and
+// converts each value to the JSON shape Jira expects for the field's type.
+// Map values are passed through unchanged, as an escape hatch for shapes the
+// conversion does not know.
+func (c *Client) ConvertFieldValues(ctx context.Context, values map[string]interface{}) (map[string]interface{}, error) {
+	converted := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		f, err := c.FindField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := c.ConvertFieldValue(ctx, f.Schema, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		converted[f.ID] = v
+	}
+	return converted, nil
+}
+
+// SetExtraFields attaches the raw values of fields to issue.ExtraFields.
+// Fields without a value on the issue are included with a nil Raw.
+func SetExtraFields(issue *Issue, fields []Field) {
+	issue.ExtraFields = nil
+	for _, f := range fields {
+		fv := FieldValue{Field: f}
+		if raw, ok := issue.Fields.Raw[f.ID]; ok && string(raw) != "null" {
+			fv.Raw = raw
+		}
+		issue.ExtraFields = append(issue.ExtraFields, fv)
+	}
+}
+
+// ConvertFieldValue converts a YAML value into the request shape for schema.
+func (c *Client) ConvertFieldValue(ctx context.Context, schema FieldSchema, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if _, ok := value.(map[string]interface{}); ok {
+		return value, nil
+	}
+
+	if schema.Type == "array" {
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		out := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			v, err := c.convertScalar(ctx, schema.Items, item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	if _, ok := value.([]interface{}); ok {
+		return nil, fmt.Errorf("expected a single %s value, got a list", schema.Type)
+	}
+	return c.convertScalar(ctx, schema.Type, value)
+}
+
+// ConvertFieldItem converts a single element of a list field, as used by the
+// add and remove update operations.
+func (c *Client) ConvertFieldItem(ctx context.Context, schema FieldSchema, value interface{}) (interface{}, error) {
+	if schema.Type != "array" {
+		return nil, fmt.Errorf("not a list field (type %s)", schema.Type)
+	}
+	return c.convertScalar(ctx, schema.Items, value)
+}
+
+// convertScalar converts a single value for the given schema type. Users are
+// looked up with FindUser, as Cloud identifies them by account ID only.
+func (c *Client) convertScalar(ctx context.Context, typ string, value interface{}) (interface{}, error) {
+	if _, ok := value.(map[string]interface{}); ok {
+		return value, nil
+	}
+	switch typ {
+	case "number":
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected a number, got %q", v)
+			}
+			return n, nil
+		}
+		return nil, fmt.Errorf("expected a number, got %v", value)
+	case "date":
+		if t, ok := value.(time.Time); ok {
+			return t.Format("2006-01-02"), nil
+		}
+		s := fmt.Sprint(value)
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, fmt.Errorf("expected a date as YYYY-MM-DD, got %q", s)
+		}
+		return s, nil
+	case "datetime":
+		if t, ok := value.(time.Time); ok {
+			return FormatTime(t), nil
+		}
+		return fmt.Sprint(value), nil
+	case "option", "option-with-child":
+		// "Parent / Child" selects a child option of a cascading select.
+		s := fmt.Sprint(value)
+		if parent, child, ok := strings.Cut(s, " / "); ok && typ == "option-with-child" {
+			return map[string]interface{}{"value": parent, "child": map[string]interface{}{"value": child}}, nil
+		}
+		return map[string]interface{}{"value": s}, nil
+	case "user":
+		user, err := c.FindUser(ctx, fmt.Sprint(value))
+		if err != nil {
+			return nil, err
+		}
+		if user.AccountID != "" {
+			return map[string]interface{}{"accountId": user.AccountID}, nil
+		}
+		return map[string]interface{}{"name": user.Name}, nil
+	case "group", "version", "component", "priority", "resolution", "issuetype":
+		return map[string]interface{}{"name": fmt.Sprint(value)}, nil
+	case "project", "issuelink":
+		return map[string]interface{}{"key": fmt.Sprint(value)}, nil
+	case "string":
+		return fmt.Sprint(value), nil
+	default:
+		// Types such as "any" (Epic Link) take the value as-is.
+		return value, nil
+	}
+}
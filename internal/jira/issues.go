@@ -1,35 +1,51 @@
 package jira
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
 )
 
 // GetIssue fetches a single issue by key (e.g. "PROJ-123").
-// If the issue is an Epic, it automatically fetches the issues in the epic.
-func (c *Client) GetIssue(key string) (*Issue, error) {
-	var issue Issue
-	path := fmt.Sprintf("/rest/api/2/issue/%s", url.PathEscape(key))
-	if err := c.do("GET", path, &issue); err != nil {
+// If the issue is an Epic, it also fetches the issues in the epic, as
+// configured by WithEpicChildren.
+func (c *Client) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	issue, err := c.GetIssueWithoutEpicChildren(ctx, key)
+	if err != nil {
 		return nil, err
 	}
 
-	// If the issue is an Epic, fetch its children via JQL
-	if issue.Fields.IssueType != nil && isEpicType(issue.Fields.IssueType.Name) {
-		jql := fmt.Sprintf(`"Epic Link" = %s ORDER BY status ASC, key ASC`, key)
-		result, err := c.Search(jql, 100)
-		if err == nil && len(result.Issues) > 0 {
-			issue.EpicChildren = result.Issues
+	if issue.Fields.IssueType != nil && IsEpicType(issue.Fields.IssueType.Name) {
+		children, err := c.GetEpicChildren(ctx, issue.Key)
+		if err != nil {
+			return nil, err
 		}
+		issue.EpicChildren = children
 	}
 
+	return issue, nil
+}
+
+// GetIssueWithoutEpicChildren fetches a single issue like GetIssue, but
+// never the issues in an epic.
+func (c *Client) GetIssueWithoutEpicChildren(ctx context.Context, key string) (*Issue, error) {
+	var issue Issue
+	path := fmt.Sprintf("/rest/api/2/issue/%s", url.PathEscape(key))
+	if err := c.do(ctx, "GET", path, &issue); err != nil {
+		return nil, err
+	}
+	fm, err := c.FieldMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fm.decodeCustomFields(&issue.Fields)
 	return &issue, nil
 }
 
-// isEpicType checks if the issue type name represents an Epic.
+// IsEpicType checks if the issue type name represents an Epic.
 // Handles both English ("Epic") and Norwegian ("Epos") names.
-func isEpicType(name string) bool {
+func IsEpicType(name string) bool {
 	switch name {
 	case "Epic", "Epos":
 		return true
@@ -37,39 +53,169 @@ func isEpicType(name string) bool {
 	return false
 }
 
-// Search executes a JQL query and returns matching issues.
-func (c *Client) Search(jql string, maxResults int) (*SearchResult, error) {
+// searchPageSize is the page size requested when paging through search results.
+// Jira may cap this further; the loop in SearchAll follows whatever it returns.
+const searchPageSize = 100
+
+// Search executes a JQL query and returns a single page of matching issues.
+func (c *Client) Search(ctx context.Context, jql string, maxResults int) (*SearchResult, error) {
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cloud {
+		return c.searchCloud(ctx, jql, maxResults, false)
+	}
+	return c.searchPage(ctx, jql, 0, maxResults)
+}
+
+// SearchAll executes a JQL query and follows startAt pages until every matching
+// issue has been fetched, or until limit issues have been fetched if limit > 0.
+// Jira Cloud pages with tokens instead.
+func (c *Client) SearchAll(ctx context.Context, jql string, limit int) (*SearchResult, error) {
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cloud {
+		return c.searchCloud(ctx, jql, limit, true)
+	}
+
+	all := &SearchResult{}
+	for {
+		pageSize := searchPageSize
+		if limit > 0 && limit-len(all.Issues) < pageSize {
+			pageSize = limit - len(all.Issues)
+		}
+
+		page, err := c.searchPage(ctx, jql, len(all.Issues), pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		all.Total = page.Total
+		all.Issues = append(all.Issues, page.Issues...)
+
+		if len(page.Issues) == 0 || len(all.Issues) >= page.Total {
+			break
+		}
+		if limit > 0 && len(all.Issues) >= limit {
+			break
+		}
+	}
+	all.MaxResults = len(all.Issues)
+	return all, nil
+}
+
+// searchPage fetches one page of JQL results starting at startAt.
+func (c *Client) searchPage(ctx context.Context, jql string, startAt, maxResults int) (*SearchResult, error) {
 	var result SearchResult
 	params := url.Values{}
 	params.Set("jql", jql)
+	params.Set("startAt", strconv.Itoa(startAt))
 	params.Set("maxResults", strconv.Itoa(maxResults))
 	path := "/rest/api/2/search?" + params.Encode()
-	if err := c.do("GET", path, &result); err != nil {
+	if err := c.do(ctx, "GET", path, &result); err != nil {
 		return nil, err
 	}
+	fm, err := c.FieldMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Issues {
+		fm.decodeCustomFields(&result.Issues[i].Fields)
+	}
 	return &result, nil
 }
 
 // CreateIssue creates a new Jira issue and returns the created issue.
-func (c *Client) CreateIssue(req *IssueCreateRequest) (*Issue, error) {
+func (c *Client) CreateIssue(ctx context.Context, req *IssueCreateRequest) (*Issue, error) {
+	if hasCustomCreateFields(&req.Fields) {
+		fm, err := c.FieldMap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := fm.encodeCreateFields(&req.Fields); err != nil {
+			return nil, err
+		}
+	}
+
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cloud || req.Fields.Markdown {
+		// Rich text goes through Custom, which can hold ADF documents.
+		if req.Fields.Custom == nil {
+			req.Fields.Custom = map[string]interface{}{}
+		}
+		if req.Fields.Description != "" {
+			req.Fields.Custom["description"] = req.Fields.Description
+			req.Fields.Description = ""
+		}
+		if req.Fields.Environment != "" {
+			req.Fields.Custom["environment"] = req.Fields.Environment
+			req.Fields.Environment = ""
+		}
+		if err := c.encodeRichText(ctx, req.Fields.Custom, nil, req.Fields.Markdown); err != nil {
+			return nil, err
+		}
+	}
+
 	var response struct {
 		Key string `json:"key"`
 	}
-	
-	if err := c.doWithBody("POST", "/rest/api/2/issue", req, &response); err != nil {
+
+	if err := c.doWithBody(ctx, "POST", "/rest/api/2/issue", req, &response); err != nil {
 		return nil, err
 	}
-	
-	return c.GetIssue(response.Key)
+
+	return c.GetIssue(ctx, response.Key)
 }
 
 // UpdateIssue updates an existing Jira issue and returns the updated issue.
-func (c *Client) UpdateIssue(key string, req *IssueUpdateRequest) (*Issue, error) {
+func (c *Client) UpdateIssue(ctx context.Context, key string, req *IssueUpdateRequest) (*Issue, error) {
 	path := fmt.Sprintf("/rest/api/2/issue/%s", url.PathEscape(key))
-	
-	if err := c.doWithBody("PUT", path, req, nil); err != nil {
+
+	if hasCustomUpdateFields(&req.Fields) {
+		fm, err := c.FieldMap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := fm.encodeUpdateFields(&req.Fields); err != nil {
+			return nil, err
+		}
+	}
+
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
 		return nil, err
 	}
-	
-	return c.GetIssue(key)
+	if cloud || req.Fields.Markdown {
+		// Rich text goes through Custom, which can hold ADF documents. An
+		// empty value clears the field.
+		if req.Fields.Custom == nil {
+			req.Fields.Custom = map[string]interface{}{}
+		}
+		for id, v := range map[string]**string{"description": &req.Fields.Description, "environment": &req.Fields.Environment} {
+			if *v == nil {
+				continue
+			}
+			if **v == "" {
+				req.Fields.Custom[id] = nil
+			} else {
+				req.Fields.Custom[id] = **v
+			}
+			*v = nil
+		}
+		if err := c.encodeRichText(ctx, req.Fields.Custom, req.Update, req.Fields.Markdown); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.doWithBody(ctx, "PUT", path, req, nil); err != nil {
+		return nil, err
+	}
+
+	return c.GetIssue(ctx, key)
 }
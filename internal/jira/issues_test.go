@@ -0,0 +1,78 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// newSearchServer serves total issues from /rest/api/2/search, at most
+// pageCap per page, and records the startAt/maxResults of each request.
+func newSearchServer(t *testing.T, total, pageCap int) (*Client, *[]string) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/field":
+			fmt.Fprint(w, "[]")
+		case "/rest/api/2/search":
+			q := r.URL.Query()
+			startAt, _ := strconv.Atoi(q.Get("startAt"))
+			maxResults, _ := strconv.Atoi(q.Get("maxResults"))
+			requests = append(requests, fmt.Sprintf("%d/%d", startAt, maxResults))
+
+			result := SearchResult{StartAt: startAt, MaxResults: maxResults, Total: total, Issues: []Issue{}}
+			for i := startAt; i < total && i < startAt+min(maxResults, pageCap); i++ {
+				result.Issues = append(result.Issues, Issue{Key: fmt.Sprintf("P-%d", i+1)})
+			}
+			json.NewEncoder(w).Encode(result)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, nil, false, WithDeployment(DeploymentServer)), &requests
+}
+
+func TestSearchAll(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		pageCap      int
+		limit        int
+		wantIssues   int
+		wantRequests []string
+	}{
+		{"single page", 30, 100, 0, 30, []string{"0/100"}},
+		{"exact pages", 200, 100, 0, 200, []string{"0/100", "100/100"}},
+		{"last page partial", 250, 100, 0, 250, []string{"0/100", "100/100", "200/100"}},
+		{"server caps page size", 120, 50, 0, 120, []string{"0/100", "50/100", "100/100"}},
+		{"no results", 0, 100, 0, 0, []string{"0/100"}},
+		{"limit within first page", 250, 100, 20, 20, []string{"0/20"}},
+		{"limit across pages", 250, 100, 120, 120, []string{"0/100", "100/20"}},
+		{"limit above total", 30, 100, 500, 30, []string{"0/100"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newSearchServer(t, tt.total, tt.pageCap)
+			result, err := client.SearchAll(context.Background(), "project = P ORDER BY key", tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Issues) != tt.wantIssues || result.MaxResults != tt.wantIssues || result.Total != tt.total {
+				t.Errorf("got %d issues, maxResults %d, total %d; want %d, %d, %d",
+					len(result.Issues), result.MaxResults, result.Total, tt.wantIssues, tt.wantIssues, tt.total)
+			}
+			if tt.wantIssues > 0 && result.Issues[tt.wantIssues-1].Key != fmt.Sprintf("P-%d", tt.wantIssues) {
+				t.Errorf("last issue is %s, want P-%d", result.Issues[tt.wantIssues-1].Key, tt.wantIssues)
+			}
+			if !reflect.DeepEqual(*requests, tt.wantRequests) {
+				t.Errorf("requests %v, want %v", *requests, tt.wantRequests)
+			}
+		})
+	}
+}
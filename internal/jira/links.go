@@ -0,0 +1,90 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// LinkRequest is the payload for linking two issues. Jira reads it as
+// "<InwardIssue> <Type.Outward> <OutwardIssue>", e.g. "A blocks B".
+type LinkRequest struct {
+	Type         LinkTypeRef `json:"type"`
+	InwardIssue  IssueRef    `json:"inwardIssue"`
+	OutwardIssue IssueRef    `json:"outwardIssue"`
+}
+
+// LinkTypeRef is a reference to an issue link type by name.
+type LinkTypeRef struct {
+	Name string `json:"name"`
+}
+
+// RemoteLink is a web link attached to an issue.
+type RemoteLink struct {
+	Object RemoteLinkObject `json:"object"`
+}
+
+// RemoteLinkObject describes the target of a remote link.
+type RemoteLinkObject struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// GetLinkTypes returns the issue link types configured on the instance.
+func (c *Client) GetLinkTypes(ctx context.Context) ([]IssueLinkType, error) {
+	var result struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := c.do(ctx, "GET", "/rest/api/2/issueLinkType", &result); err != nil {
+		return nil, err
+	}
+	return result.IssueLinkTypes, nil
+}
+
+// FindLinkType matches phrase case-insensitively against the name and the
+// outward and inward descriptions of types, so both "Blocks", "blocks" and
+// "is blocked by" (or their localized wording) are accepted. reversed is
+// true when phrase matched an inward description, i.e. the issues must be
+// swapped to read in the outward direction.
+func FindLinkType(types []IssueLinkType, phrase string) (t IssueLinkType, reversed bool, err error) {
+	phrase = strings.TrimSpace(phrase)
+	for _, lt := range types {
+		if strings.EqualFold(lt.Name, phrase) || strings.EqualFold(lt.Outward, phrase) {
+			return lt, false, nil
+		}
+	}
+	for _, lt := range types {
+		if strings.EqualFold(lt.Inward, phrase) {
+			return lt, true, nil
+		}
+	}
+
+	available := make([]string, len(types))
+	for i, lt := range types {
+		available[i] = fmt.Sprintf("%q / %q", lt.Outward, lt.Inward)
+	}
+	return IssueLinkType{}, false, fmt.Errorf("no link type matches %q; available: %s", phrase, strings.Join(available, ", "))
+}
+
+// LinkIssues creates a link reading "<from> <linkType.Outward> <to>".
+func (c *Client) LinkIssues(ctx context.Context, from, linkType, to string) error {
+	req := &LinkRequest{
+		Type:         LinkTypeRef{Name: linkType},
+		InwardIssue:  IssueRef{Key: from},
+		OutwardIssue: IssueRef{Key: to},
+	}
+	return c.doWithBody(ctx, "POST", "/rest/api/2/issueLink", req, nil)
+}
+
+// DeleteLink removes an issue link by ID.
+func (c *Client) DeleteLink(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/rest/api/2/issueLink/"+url.PathEscape(id), nil)
+}
+
+// AddRemoteLink attaches a web link to an issue.
+func (c *Client) AddRemoteLink(ctx context.Context, key, linkURL, title string) error {
+	req := &RemoteLink{Object: RemoteLinkObject{URL: linkURL, Title: title}}
+	path := fmt.Sprintf("/rest/api/2/issue/%s/remotelink", url.PathEscape(key))
+	return c.doWithBody(ctx, "POST", path, req, nil)
+}
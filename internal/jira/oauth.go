@@ -0,0 +1,217 @@
+package jira
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth 1.0a endpoints of a Jira application link.
+const (
+	oauthRequestTokenPath = "/plugins/servlet/oauth/request-token"
+	oauthAuthorizePath    = "/plugins/servlet/oauth/authorize"
+	oauthAccessTokenPath  = "/plugins/servlet/oauth/access-token"
+)
+
+// OAuth1 signs requests with OAuth 1.0a RSA-SHA1, as used by Jira
+// application links. ConsumerKey and PrivateKey identify the application
+// link; Token is the access token obtained with OAuthRequestToken and
+// OAuthAccessToken.
+type OAuth1 struct {
+	ConsumerKey string
+	PrivateKey  *rsa.PrivateKey
+	Token       string
+
+	// extra holds protocol parameters for the token requests, such as
+	// oauth_callback and oauth_verifier.
+	extra map[string]string
+}
+
+// oauthNow and oauthNonce supply the timestamp and nonce of a signature;
+// tests replace them for reproducible signatures.
+var (
+	oauthNow   = time.Now
+	oauthNonce = func() (string, error) {
+		nonce := make([]byte, 16)
+		if _, err := rand.Read(nonce); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(nonce), nil
+	}
+)
+
+// Authenticate implements Authenticator.
+func (a *OAuth1) Authenticate(req *http.Request) error {
+	nonce, err := oauthNonce()
+	if err != nil {
+		return fmt.Errorf("generating OAuth nonce: %w", err)
+	}
+	params := map[string]string{
+		"oauth_consumer_key":     a.ConsumerKey,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(oauthNow().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if a.Token != "" {
+		params["oauth_token"] = a.Token
+	}
+	for k, v := range a.extra {
+		params[k] = v
+	}
+
+	digest := sha1.Sum([]byte(oauthBaseString(req, params)))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, a.PrivateKey, crypto.SHA1, digest[:])
+	if err != nil {
+		return fmt.Errorf("signing OAuth request: %w", err)
+	}
+	params["oauth_signature"] = base64.StdEncoding.EncodeToString(sig)
+
+	parts := make([]string, 0, len(params))
+	for k, v := range params {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, oauthEscape(k), oauthEscape(v)))
+	}
+	sort.Strings(parts)
+	req.Header.Set("Authorization", "OAuth "+strings.Join(parts, ", "))
+	return nil
+}
+
+// oauthBaseString builds the signature base string of RFC 5849 section
+// 3.4.1 from the request method, URL and query, and the protocol parameters.
+// Request bodies are JSON or multipart, so they never contribute.
+func oauthBaseString(req *http.Request, params map[string]string) string {
+	var pairs []string
+	for k, vs := range req.URL.Query() {
+		for _, v := range vs {
+			pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	for k, v := range params {
+		pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+	}
+	sort.Strings(pairs)
+
+	u := *req.URL
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Hostname()
+	}
+	u.RawQuery, u.Fragment = "", ""
+
+	return strings.ToUpper(req.Method) + "&" + oauthEscape(u.String()) + "&" + oauthEscape(strings.Join(pairs, "&"))
+}
+
+// oauthEscape percent-encodes s as RFC 5849 requires: everything but
+// unreserved characters, with spaces as %20.
+func oauthEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// LoadPrivateKey reads a PEM-encoded RSA private key in PKCS #1 or PKCS #8
+// form, as generated for a Jira application link.
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s contains no PEM data", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an RSA private key", path)
+	}
+	return key, nil
+}
+
+// OAuthRequestToken starts the OAuth dance for the application link
+// identified by consumerKey and key, and returns a request token for the
+// user to authorize at OAuthAuthorizeURL.
+func (c *Client) OAuthRequestToken(ctx context.Context, consumerKey string, key *rsa.PrivateKey) (string, error) {
+	auth := &OAuth1{ConsumerKey: consumerKey, PrivateKey: key, extra: map[string]string{"oauth_callback": "oob"}}
+	values, err := c.oauthTokenRequest(ctx, oauthRequestTokenPath, auth)
+	if err != nil {
+		return "", fmt.Errorf("requesting OAuth request token: %w", err)
+	}
+	return values.Get("oauth_token"), nil
+}
+
+// OAuthAuthorizeURL returns the page where the user authorizes requestToken.
+func (c *Client) OAuthAuthorizeURL(requestToken string) string {
+	return c.baseURL + oauthAuthorizePath + "?oauth_token=" + url.QueryEscape(requestToken)
+}
+
+// OAuthAccessToken exchanges an authorized request token and the verifier
+// code Jira showed the user for an access token, for OAuth1.Token.
+func (c *Client) OAuthAccessToken(ctx context.Context, consumerKey string, key *rsa.PrivateKey, requestToken, verifier string) (string, error) {
+	auth := &OAuth1{ConsumerKey: consumerKey, PrivateKey: key, Token: requestToken, extra: map[string]string{"oauth_verifier": verifier}}
+	values, err := c.oauthTokenRequest(ctx, oauthAccessTokenPath, auth)
+	if err != nil {
+		return "", fmt.Errorf("requesting OAuth access token: %w", err)
+	}
+	return values.Get("oauth_token"), nil
+}
+
+// oauthTokenRequest posts a signed token request and parses the
+// form-encoded reply.
+func (c *Client) oauthTokenRequest(ctx context.Context, path string, auth *OAuth1) (url.Values, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if err := auth.Authenticate(req); err != nil {
+		return nil, err
+	}
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, ">>> POST %s\n", req.URL)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "<<< HTTP %d\n%s\n", resp.StatusCode, string(body))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, fmt.Errorf("no oauth_token in response: %s", string(body))
+	}
+	return values, nil
+}
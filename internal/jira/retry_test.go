@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"first retry", DefaultRetryPolicy, 1, 250 * time.Millisecond, 500 * time.Millisecond},
+		{"doubles per attempt", DefaultRetryPolicy, 3, time.Second, 2 * time.Second},
+		{"capped by MaxDelay", DefaultRetryPolicy, 10, 15 * time.Second, 30 * time.Second},
+		{"cap between steps", RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}, 3, 1500 * time.Millisecond, 3 * time.Second},
+		{"uncapped", RetryPolicy{BaseDelay: 100 * time.Millisecond}, 5, 800 * time.Millisecond, 1600 * time.Millisecond},
+		{"no base delay", RetryPolicy{MaxDelay: time.Second}, 3, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 200 {
+				if d := tt.policy.backoff(tt.attempt); d < tt.min || d > tt.max {
+					t.Fatalf("backoff(%d) = %s, want within [%s, %s]", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"absent", "", 0, false},
+		{"seconds", "120", 120 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"negative seconds", "-5", 0, false},
+		{"garbage", "soon", 0, false},
+		{"past date", "Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.value != "" {
+				h.Set("Retry-After", tt.value)
+			}
+			got, ok := retryAfter(h)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("future date", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("Retry-After", time.Now().Add(90*time.Second).UTC().Format(http.TimeFormat))
+		got, ok := retryAfter(h)
+		// HTTP dates have whole seconds, so up to a second is lost.
+		if !ok || got < 88*time.Second || got > 90*time.Second {
+			t.Errorf("retryAfter(90s from now) = %s, %v; want about 90s, true", got, ok)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Transition is a workflow transition available on an issue.
+type Transition struct {
+	ID     string                     `json:"id"`
+	Name   string                     `json:"name"`
+	To     *Status                    `json:"to"`
+	Fields map[string]TransitionField `json:"fields"`
+}
+
+// TransitionField describes a field on a transition screen.
+type TransitionField struct {
+	Name     string      `json:"name"`
+	Required bool        `json:"required"`
+	Schema   FieldSchema `json:"schema"`
+}
+
+// TransitionRequest is the payload for performing a transition.
+type TransitionRequest struct {
+	Transition TransitionRef                       `json:"transition"`
+	Fields     map[string]interface{}              `json:"fields,omitempty"`
+	Update     map[string][]map[string]interface{} `json:"update,omitempty"`
+}
+
+// TransitionRef is a reference to a transition by ID.
+type TransitionRef struct {
+	ID string `json:"id"`
+}
+
+// TransitionInput is the user-friendly YAML input for a transition.
+type TransitionInput struct {
+	Resolution string                 `yaml:"resolution"`
+	Comment    string                 `yaml:"comment"`
+	Fields     map[string]interface{} `yaml:"fields"`
+}
+
+// GetTransitions lists the transitions currently available on an issue,
+// including the fields on each transition screen.
+func (c *Client) GetTransitions(ctx context.Context, key string) ([]Transition, error) {
+	var response struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	path := fmt.Sprintf("/rest/api/2/issue/%s/transitions?expand=transitions.fields", url.PathEscape(key))
+	if err := c.do(ctx, "GET", path, &response); err != nil {
+		return nil, err
+	}
+	return response.Transitions, nil
+}
+
+// DoTransition performs a transition on an issue.
+func (c *Client) DoTransition(ctx context.Context, key string, req *TransitionRequest) error {
+	path := fmt.Sprintf("/rest/api/2/issue/%s/transitions", url.PathEscape(key))
+
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
+		return err
+	}
+	if cloud {
+		if err := c.encodeRichText(ctx, req.Fields, nil, false); err != nil {
+			return err
+		}
+		for _, op := range req.Update["comment"] {
+			if add, ok := op["add"].(map[string]interface{}); ok {
+				if body, ok := add["body"].(string); ok {
+					add["body"] = wikiToADF(body)
+				}
+			}
+		}
+	}
+	return c.doWithBody(ctx, "POST", path, req, nil)
+}
+
+// FindTransition picks the transition whose target status or name matches
+// target, case-insensitively. Target statuses take precedence, so
+// "I gang" matches a transition named "Start arbeid" leading to "I gang".
+func FindTransition(transitions []Transition, target string) (*Transition, error) {
+	for i, t := range transitions {
+		if t.To != nil && strings.EqualFold(t.To.Name, target) {
+			return &transitions[i], nil
+		}
+	}
+	for i, t := range transitions {
+		if strings.EqualFold(t.Name, target) {
+			return &transitions[i], nil
+		}
+	}
+
+	available := make([]string, len(transitions))
+	for i, t := range transitions {
+		available[i] = fmt.Sprintf("%q", t.Name)
+		if t.To != nil {
+			available[i] += fmt.Sprintf(" (→ %s)", t.To.Name)
+		}
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("no transition matches %q: no transitions are available", target)
+	}
+	return nil, fmt.Errorf("no transition matches %q; available: %s", target, strings.Join(available, ", "))
+}
@@ -0,0 +1,175 @@
+package jira
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TransportOptions configures TLS and proxying for the connection to Jira.
+// The zero value behaves like http.DefaultTransport.
+type TransportOptions struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition
+	// to the system pool, e.g. an internal CA.
+	CAFile string
+
+	// ClientCert and ClientKey are PEM files with a client certificate and
+	// its key for mutual TLS. ClientKey defaults to ClientCert, for a
+	// combined file.
+	ClientCert string
+	ClientKey  string
+
+	// Insecure disables verification of the server certificate.
+	Insecure bool
+
+	// Proxy is the URL of the proxy to use instead of HTTP_PROXY and
+	// HTTPS_PROXY. A bare host:port is taken as an HTTP proxy.
+	Proxy string
+
+	// NoProxy lists hosts to connect to directly, in addition to NO_PROXY:
+	// comma-separated host names (matching subdomains too), .domain
+	// suffixes, IP addresses, CIDR ranges, any with a :port, or "*".
+	NoProxy string
+}
+
+// WithTransport sets the RoundTripper used for requests, as built by
+// NewTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// NewTransport builds an http.Transport from http.DefaultTransport with the
+// TLS and proxy settings in opts.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.Insecure,
+	}
+
+	if opts.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		data, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("CA file %s contains no PEM certificates", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" {
+			return nil, fmt.Errorf("a client key needs a client certificate")
+		}
+		keyFile := opts.ClientKey
+		if keyFile == "" {
+			keyFile = opts.ClientCert
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	t.TLSClientConfig = tlsConfig
+
+	if opts.Proxy != "" || opts.NoProxy != "" {
+		proxy, err := proxyFunc(opts.Proxy, opts.NoProxy)
+		if err != nil {
+			return nil, err
+		}
+		t.Proxy = proxy
+	}
+	return t, nil
+}
+
+// proxyFunc returns a Transport.Proxy function that sends requests through
+// proxy, or the proxy from the environment if proxy is empty, except for
+// hosts matched by noProxy or NO_PROXY.
+func proxyFunc(proxy, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	var fixed *url.URL
+	if proxy != "" {
+		if !strings.Contains(proxy, "://") {
+			proxy = "http://" + proxy
+		}
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+		}
+		fixed = u
+		// http.ProxyFromEnvironment applies NO_PROXY itself; a fixed
+		// proxy bypasses it, so merge it into the list.
+		noProxy += "," + envNoProxy()
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+		if fixed != nil {
+			return fixed, nil
+		}
+		return http.ProxyFromEnvironment(req)
+	}, nil
+}
+
+// envNoProxy returns NO_PROXY, or no_proxy if that is unset.
+func envNoProxy() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
+
+// bypassProxy reports whether u matches an entry in the no-proxy list.
+func bypassProxy(u *url.URL, noProxy string) bool {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	ip := net.ParseIP(host)
+
+	for _, entry := range strings.FieldsFunc(strings.ToLower(noProxy), func(r rune) bool { return r == ',' || r == ' ' }) {
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost := entry
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			if p != port {
+				continue
+			}
+			entryHost = h
+		}
+		entryHost = strings.TrimPrefix(entryHost, "*")
+		if strings.HasPrefix(entryHost, ".") {
+			if strings.HasSuffix(host, entryHost) || host == entryHost[1:] {
+				return true
+			}
+			continue
+		}
+		if host == entryHost || strings.HasSuffix(host, "."+entryHost) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package jira
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestBypassProxy(t *testing.T) {
+	tests := []struct {
+		url     string
+		noProxy string
+		want    bool
+	}{
+		{"https://jira.example.com", "", false},
+		{"https://jira.example.com", "*", true},
+		{"https://jira.example.com", "jira.example.com", true},
+		{"https://JIRA.Example.com", "jira.example.com", true},
+		{"https://jira.example.com", "example.com", true},
+		{"https://jira.example.com", "ample.com", false},
+		{"https://jira.example.com", ".example.com", true},
+		{"https://example.com", ".example.com", true},
+		{"https://jira.example.com", "*.example.com", true},
+		{"https://jira.example.com", ".other.com", false},
+		{"https://jira.example.com", "other.com, example.com", true},
+		{"https://jira.example.com", "jira.example.com:443", true},
+		{"https://jira.example.com", "jira.example.com:8443", false},
+		{"https://jira.example.com:8443", "jira.example.com:8443", true},
+		{"http://jira.example.com", "jira.example.com:80", true},
+		{"http://10.1.2.3:8080", "10.0.0.0/8", true},
+		{"http://192.168.1.1", "10.0.0.0/8", false},
+		{"http://jira.example.com", "10.0.0.0/8", false},
+		{"http://10.1.2.3", "10.1.2.3", true},
+		{"http://[::1]:8080", "::1/128", true},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bypassProxy(u, tt.noProxy); got != tt.want {
+			t.Errorf("bypassProxy(%s, %q) = %v, want %v", tt.url, tt.noProxy, got, tt.want)
+		}
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	t.Setenv("NO_PROXY", "env.example.com")
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("HTTP_PROXY", "")
+
+	proxy, err := proxyFunc("proxy.corp:3128", "flag.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://jira.example.com/rest", "http://proxy.corp:3128"},
+		{"https://flag.example.com/rest", ""},
+		{"https://env.example.com/rest", ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if u != nil {
+			got = u.String()
+		}
+		if got != tt.want {
+			t.Errorf("proxy for %s = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	if _, err := proxyFunc("http://bad host", ""); err == nil {
+		t.Error("proxyFunc accepted an invalid proxy URL")
+	}
+}
@@ -1,13 +1,25 @@
 package jira
 
+import "encoding/json"
+
 // User represents a Jira user.
 type User struct {
 	Key          string `json:"key"`
 	Name         string `json:"name"`
+	AccountID    string `json:"accountId,omitempty"`
 	DisplayName  string `json:"displayName"`
 	EmailAddress string `json:"emailAddress"`
 }
 
+// ID returns the identifier Jira uses for the user in JQL and requests: the
+// account ID on Jira Cloud, the username on Server/Data Center.
+func (u *User) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
+}
+
 // Issue represents a Jira issue.
 type Issue struct {
 	Key    string      `json:"key"`
@@ -17,6 +29,10 @@ type Issue struct {
 	// EpicChildren holds issues belonging to this epic.
 	// Not populated from JSON — filled by a separate API call.
 	EpicChildren []Issue `json:"-"`
+
+	// ExtraFields holds additional fields requested for display.
+	// Not populated from JSON — filled by SetExtraFields.
+	ExtraFields []FieldValue `json:"-"`
 }
 
 // IssueFields contains the fields of a Jira issue.
@@ -38,12 +54,55 @@ type IssueFields struct {
 	Subtasks    []Issue     `json:"subtasks"`
 	Parent      *Issue      `json:"parent"`
 	Resolution  *Resolution `json:"resolution"`
-	EpicLink    string      `json:"customfield_10761"`
-	ParentLink  string      `json:"customfield_13677"`
+	FixVersions []Version   `json:"fixVersions"`
+	Versions    []Version   `json:"versions"`
+	DueDate     string      `json:"duedate"`
+	Environment string      `json:"environment"`
+
+	TimeTracking *TimeTracking `json:"timetracking"`
+	Attachments  []Attachment  `json:"attachment"`
+
+	// Custom-field-backed values, filled from Raw through a FieldMap since
+	// their field IDs differ between Jira instances.
+	EpicLink    string   `json:"-"`
+	ParentLink  string   `json:"-"`
+	StoryPoints *float64 `json:"-"`
+	Sprints     []string `json:"-"`
+
+	// Raw holds every field value as returned by Jira, keyed by field ID.
+	Raw map[string]json.RawMessage `json:"-"`
+
+	// Markdown is set when the rich text fields hold Markdown converted from
+	// Atlassian Document Format (Jira Cloud) rather than wiki markup.
+	Markdown bool `json:"-"`
+}
+
+// UnmarshalJSON decodes the standard fields and keeps every raw value in Raw,
+// so custom fields can be resolved later through a FieldMap. Rich text in
+// Atlassian Document Format is converted to Markdown.
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	data, markdown, err := flattenADF(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	f.Markdown = markdown
+	return json.Unmarshal(data, &f.Raw)
 }
 
 // Status represents an issue status.
 type Status struct {
+	Name     string          `json:"name"`
+	Category *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory groups statuses into to do ("new"), in progress
+// ("indeterminate") and done ("done").
+type StatusCategory struct {
+	Key  string `json:"key"`
 	Name string `json:"name"`
 }
 
@@ -69,22 +128,113 @@ type Component struct {
 	Name string `json:"name"`
 }
 
+// Version represents a project version (fix version or affects version).
+type Version struct {
+	Name     string `json:"name"`
+	Released bool   `json:"released"`
+}
+
 // Comments wraps a list of comments.
 type Comments struct {
-	Comments []Comment `json:"comments"`
-	Total    int       `json:"total"`
+	Comments   []Comment `json:"comments"`
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
 }
 
 // Comment represents an issue comment.
 type Comment struct {
-	Author  *User  `json:"author"`
-	Body    string `json:"body"`
-	Created string `json:"created"`
-	Updated string `json:"updated"`
+	ID         string      `json:"id"`
+	Author     *User       `json:"author"`
+	Body       string      `json:"body"`
+	Created    string      `json:"created"`
+	Updated    string      `json:"updated"`
+	Visibility *Visibility `json:"visibility,omitempty"`
+
+	// Markdown is set when Body is Markdown converted from Atlassian
+	// Document Format (Jira Cloud) rather than wiki markup.
+	Markdown bool `json:"-"`
+}
+
+// UnmarshalJSON decodes a comment, converting an ADF body to Markdown.
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type plain Comment
+	data, markdown, err := flattenADF(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	c.Markdown = markdown
+	return nil
+}
+
+// Attachment represents a file attached to an issue.
+type Attachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Author   *User  `json:"author"`
+	Created  string `json:"created"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	// Content is the download URL.
+	Content string `json:"content"`
+
+	// Text holds the content of a text attachment.
+	// Not populated from JSON — filled by LoadAttachmentText.
+	Text *string `json:"-"`
+}
+
+// Worklogs wraps a list of worklog entries.
+type Worklogs struct {
+	Worklogs   []Worklog `json:"worklogs"`
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+}
+
+// Worklog represents time logged on an issue.
+type Worklog struct {
+	ID               string      `json:"id"`
+	IssueID          string      `json:"issueId"`
+	Author           *User       `json:"author"`
+	Comment          string      `json:"comment"`
+	Started          string      `json:"started"`
+	TimeSpent        string      `json:"timeSpent"`
+	TimeSpentSeconds int         `json:"timeSpentSeconds"`
+	Created          string      `json:"created"`
+	Updated          string      `json:"updated"`
+	Visibility       *Visibility `json:"visibility,omitempty"`
+
+	// Markdown is set when Comment is Markdown converted from Atlassian
+	// Document Format (Jira Cloud) rather than wiki markup.
+	Markdown bool `json:"-"`
+}
+
+// UnmarshalJSON decodes a worklog, converting an ADF comment to Markdown.
+func (w *Worklog) UnmarshalJSON(data []byte) error {
+	type plain Worklog
+	data, markdown, err := flattenADF(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(w)); err != nil {
+		return err
+	}
+	w.Markdown = markdown
+	return nil
+}
+
+// Visibility restricts a comment or worklog to a project role or group.
+type Visibility struct {
+	Type  string `json:"type"` // "role" or "group"
+	Value string `json:"value"`
 }
 
 // IssueLink represents a link between issues.
 type IssueLink struct {
+	ID           string        `json:"id,omitempty"`
 	Type         IssueLinkType `json:"type"`
 	InwardIssue  *Issue        `json:"inwardIssue"`
 	OutwardIssue *Issue        `json:"outwardIssue"`
@@ -92,6 +242,7 @@ type IssueLink struct {
 
 // IssueLinkType represents the type of link.
 type IssueLinkType struct {
+	ID      string `json:"id,omitempty"`
 	Name    string `json:"name"`
 	Inward  string `json:"inward"`
 	Outward string `json:"outward"`
@@ -112,15 +263,34 @@ type SearchResult struct {
 
 // IssueInput is the user-friendly YAML input format.
 type IssueInput struct {
-	Project    string   `yaml:"project"`
-	Summary    string   `yaml:"summary"`
-	Description string   `yaml:"description"`
-	Type       string   `yaml:"type"`
-	Labels     []string `yaml:"labels"`
-	EpicLink   string   `yaml:"epicLink"`
-	EpicName   string   `yaml:"epicName"`
-	Parent     string   `yaml:"parent"`
-	ParentLink string   `yaml:"parentLink"`
+	Project     string `yaml:"project"`
+	Summary     string `yaml:"summary"`
+	Description string `yaml:"description"`
+	Type        string `yaml:"type"`
+
+	// DescriptionFormat is "wiki" (the default) or "markdown", the format of
+	// the description and the other rich text fields.
+	DescriptionFormat string `yaml:"descriptionFormat"`
+
+	Labels      []string `yaml:"labels"`
+	EpicLink    string   `yaml:"epicLink"`
+	EpicName    string   `yaml:"epicName"`
+	Parent      string   `yaml:"parent"`
+	ParentLink  string   `yaml:"parentLink"`
+	StoryPoints *float64 `yaml:"storyPoints"`
+
+	// Assignee is a username, email address or "me".
+	Assignee         string   `yaml:"assignee"`
+	Priority         string   `yaml:"priority"`
+	Components       []string `yaml:"components"`
+	FixVersions      []string `yaml:"fixVersions"`
+	AffectsVersions  []string `yaml:"affectsVersions"`
+	DueDate          string   `yaml:"dueDate"`
+	Environment      string   `yaml:"environment"`
+	OriginalEstimate string   `yaml:"originalEstimate"`
+
+	// Fields sets arbitrary fields by name or ID, converted by field type.
+	Fields map[string]interface{} `yaml:"fields"`
 }
 
 // IssueCreateRequest represents the payload for creating a Jira issue.
@@ -130,32 +300,96 @@ type IssueCreateRequest struct {
 
 // IssueCreateFields contains fields for creating an issue.
 type IssueCreateFields struct {
-	Project    *ProjectRef `json:"project"`
-	Summary    string      `json:"summary"`
+	Project     *ProjectRef `json:"project"`
+	Summary     string      `json:"summary"`
 	Description string      `json:"description,omitempty"`
-	IssueType  *TypeRef    `json:"issuetype"`
-	Labels     []string    `json:"labels,omitempty"`
-	EpicLink   string      `json:"customfield_10761,omitempty"`
-	EpicName   string      `json:"customfield_10764,omitempty"`
-	Parent     *IssueRef   `json:"parent,omitempty"`
-	ParentLink string      `json:"customfield_13677,omitempty"`
+	IssueType   *TypeRef    `json:"issuetype"`
+	Labels      []string    `json:"labels,omitempty"`
+	Parent      *IssueRef   `json:"parent,omitempty"`
+
+	Assignee     *UserRef      `json:"assignee,omitempty"`
+	Priority     *NameRef      `json:"priority,omitempty"`
+	Components   []NameRef     `json:"components,omitempty"`
+	FixVersions  []NameRef     `json:"fixVersions,omitempty"`
+	Versions     []NameRef     `json:"versions,omitempty"`
+	DueDate      string        `json:"duedate,omitempty"`
+	Environment  string        `json:"environment,omitempty"`
+	TimeTracking *TimeTracking `json:"timetracking,omitempty"`
+
+	// Custom-field-backed values, encoded into Custom through a FieldMap.
+	EpicLink    string   `json:"-"`
+	EpicName    string   `json:"-"`
+	ParentLink  string   `json:"-"`
+	StoryPoints *float64 `json:"-"`
+
+	// Markdown marks the rich text values (description, environment, text
+	// area custom fields and their set operations) as Markdown rather than
+	// wiki markup, converted to ADF on Jira Cloud and to wiki markup on Server.
+	Markdown bool `json:"-"`
+
+	// Custom holds extra field values keyed by field ID.
+	Custom map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON encodes the standard fields together with Custom.
+func (f IssueCreateFields) MarshalJSON() ([]byte, error) {
+	type plain IssueCreateFields
+	data, err := json.Marshal(plain(f))
+	if err != nil {
+		return nil, err
+	}
+	return mergeCustom(data, f.Custom)
 }
 
 // IssueUpdateRequest represents the payload for updating a Jira issue.
+// Fields replaces values; Update holds add/remove/set operations keyed by
+// field ID, e.g. {"labels": [{"add": "urgent"}]}.
 type IssueUpdateRequest struct {
-	Fields IssueUpdateFields `json:"fields"`
+	Fields IssueUpdateFields                   `json:"fields"`
+	Update map[string][]map[string]interface{} `json:"update,omitempty"`
 }
 
 // IssueUpdateFields contains fields for updating an issue.
 type IssueUpdateFields struct {
-	Summary    *string    `json:"summary,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	IssueType  *TypeRef   `json:"issuetype,omitempty"`
-	Labels     *[]string  `json:"labels,omitempty"`
-	EpicLink   *string    `json:"customfield_10761,omitempty"`
-	EpicName   *string    `json:"customfield_10764,omitempty"`
-	Parent     *IssueRef  `json:"parent,omitempty"`
-	ParentLink *string    `json:"customfield_13677,omitempty"`
+	Summary     *string   `json:"summary,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	IssueType   *TypeRef  `json:"issuetype,omitempty"`
+	Labels      *[]string `json:"labels,omitempty"`
+	Parent      *IssueRef `json:"parent,omitempty"`
+
+	Assignee     *UserRef      `json:"assignee,omitempty"`
+	Priority     *NameRef      `json:"priority,omitempty"`
+	Components   *[]NameRef    `json:"components,omitempty"`
+	FixVersions  *[]NameRef    `json:"fixVersions,omitempty"`
+	Versions     *[]NameRef    `json:"versions,omitempty"`
+	DueDate      *string       `json:"duedate,omitempty"`
+	Environment  *string       `json:"environment,omitempty"`
+	TimeTracking *TimeTracking `json:"timetracking,omitempty"`
+
+	// Custom-field-backed values, encoded into Custom through a FieldMap.
+	EpicLink    *string  `json:"-"`
+	EpicName    *string  `json:"-"`
+	ParentLink  *string  `json:"-"`
+	StoryPoints *float64 `json:"-"`
+
+	// Markdown marks the rich text values (description, environment, text
+	// area custom fields and their set operations) as Markdown rather than
+	// wiki markup, converted to ADF on Jira Cloud and to wiki markup on Server.
+	Markdown bool `json:"-"`
+
+	// Custom holds extra field values keyed by field ID. A nil value clears
+	// the field.
+	Custom map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON encodes the standard fields together with Custom.
+func (f IssueUpdateFields) MarshalJSON() ([]byte, error) {
+	type plain IssueUpdateFields
+	data, err := json.Marshal(plain(f))
+	if err != nil {
+		return nil, err
+	}
+	return mergeCustom(data, f.Custom)
 }
 
 // ProjectRef is a reference to a project by key.
@@ -172,3 +406,36 @@ type TypeRef struct {
 type IssueRef struct {
 	Key string `json:"key"`
 }
+
+// NameRef is a reference to a priority, component or version by name.
+type NameRef struct {
+	Name string `json:"name"`
+}
+
+// NameRefs converts a list of names to NameRefs.
+func NameRefs(names []string) []NameRef {
+	refs := make([]NameRef, len(names))
+	for i, name := range names {
+		refs[i] = NameRef{Name: name}
+	}
+	return refs
+}
+
+// UserRef is a reference to a user by username on Server/Data Center, or
+// by account ID on Jira Cloud.
+type UserRef struct {
+	Name      string `json:"name,omitempty"`
+	AccountID string `json:"accountId,omitempty"`
+}
+
+// TimeTracking holds estimates in Jira duration format, e.g. "1d 4h". The
+// time spent and the second counts are read-only.
+type TimeTracking struct {
+	OriginalEstimate  string `json:"originalEstimate,omitempty"`
+	RemainingEstimate string `json:"remainingEstimate,omitempty"`
+	TimeSpent         string `json:"timeSpent,omitempty"`
+
+	OriginalEstimateSeconds  int `json:"originalEstimateSeconds,omitempty"`
+	RemainingEstimateSeconds int `json:"remainingEstimateSeconds,omitempty"`
+	TimeSpentSeconds         int `json:"timeSpentSeconds,omitempty"`
+}
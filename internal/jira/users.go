@@ -0,0 +1,51 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FindUser resolves a username, email address or display name to a user.
+// The shorthand "me" resolves to the authenticated user. Jira Cloud has no
+// usernames and searches by email address or display name.
+func (c *Client) FindUser(ctx context.Context, query string) (*User, error) {
+	if strings.EqualFold(query, "me") {
+		return c.Myself(ctx)
+	}
+
+	cloud, err := c.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var users []User
+	params := url.Values{}
+	if cloud {
+		params.Set("query", query)
+	} else {
+		params.Set("username", query)
+	}
+	if err := c.do(ctx, "GET", "/rest/api/2/user/search?"+params.Encode(), &users); err != nil {
+		return nil, fmt.Errorf("searching for user %q: %w", query, err)
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, fmt.Errorf("no user matches %q", query)
+	case 1:
+		return &users[0], nil
+	}
+
+	// Several partial matches: accept one that matches exactly.
+	for i, u := range users {
+		if strings.EqualFold(u.Name, query) || strings.EqualFold(u.EmailAddress, query) || strings.EqualFold(u.DisplayName, query) {
+			return &users[i], nil
+		}
+	}
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = fmt.Sprintf("%s (%s)", u.ID(), u.DisplayName)
+	}
+	return nil, fmt.Errorf("user %q is ambiguous: %s", query, strings.Join(names, ", "))
+}
@@ -0,0 +1,144 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the timestamp format Jira uses for dates such as a
+// worklog's start time.
+const TimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Jira's default time tracking settings, used to convert days and weeks.
+const (
+	HoursPerDay = 8
+	DaysPerWeek = 5
+)
+
+// WorklogRequest is the payload for adding a worklog.
+type WorklogRequest struct {
+	Comment          string      `json:"comment,omitempty"`
+	Started          string      `json:"started"`
+	TimeSpentSeconds int         `json:"timeSpentSeconds"`
+	Visibility       *Visibility `json:"visibility,omitempty"`
+}
+
+// worklogPageSize is the page size requested when paging through worklogs.
+const worklogPageSize = 1000
+
+// GetWorklogs fetches every worklog on an issue, oldest first.
+func (c *Client) GetWorklogs(ctx context.Context, key string) (*Worklogs, error) {
+	all := &Worklogs{}
+	for {
+		var page Worklogs
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(len(all.Worklogs)))
+		params.Set("maxResults", strconv.Itoa(worklogPageSize))
+		path := fmt.Sprintf("/rest/api/2/issue/%s/worklog?%s", url.PathEscape(key), params.Encode())
+		if err := c.do(ctx, "GET", path, &page); err != nil {
+			return nil, err
+		}
+
+		all.Total = page.Total
+		all.Worklogs = append(all.Worklogs, page.Worklogs...)
+
+		if len(page.Worklogs) == 0 || len(all.Worklogs) >= page.Total {
+			break
+		}
+	}
+	all.MaxResults = len(all.Worklogs)
+	return all, nil
+}
+
+// AddWorklog logs time on an issue and returns the created worklog.
+func (c *Client) AddWorklog(ctx context.Context, key string, req *WorklogRequest) (*Worklog, error) {
+	var worklog Worklog
+	path := fmt.Sprintf("/rest/api/2/issue/%s/worklog", url.PathEscape(key))
+	body, err := c.worklogPayload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.doWithBody(ctx, "POST", path, body, &worklog); err != nil {
+		return nil, err
+	}
+	return &worklog, nil
+}
+
+// DeleteWorklog removes a worklog from an issue. Jira adjusts the remaining
+// estimate automatically.
+func (c *Client) DeleteWorklog(ctx context.Context, key, id string) error {
+	path := fmt.Sprintf("/rest/api/2/issue/%s/worklog/%s", url.PathEscape(key), url.PathEscape(id))
+	return c.do(ctx, "DELETE", path, nil)
+}
+
+var durationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)([wdhm]?)`)
+
+// ParseDuration converts a Jira duration such as "1h30m", "2d 4h" or "1.5h"
+// to seconds. Days and weeks use HoursPerDay and DaysPerWeek, and a bare
+// number is taken as minutes, as in Jira.
+func ParseDuration(s string) (int, error) {
+	rest := strings.ToLower(strings.TrimSpace(s))
+	if rest == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var seconds float64
+	for rest != "" {
+		m := durationPart.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("invalid duration %q (use e.g. 1h30m, 2d 4h)", s)
+		}
+		n, _ := strconv.ParseFloat(m[1], 64)
+		switch m[2] {
+		case "w":
+			seconds += n * DaysPerWeek * HoursPerDay * 3600
+		case "d":
+			seconds += n * HoursPerDay * 3600
+		case "h":
+			seconds += n * 3600
+		default:
+			seconds += n * 60
+		}
+		rest = strings.TrimLeft(rest[len(m[0]):], " ")
+	}
+
+	if seconds < 60 {
+		return 0, fmt.Errorf("duration %q is shorter than a minute", s)
+	}
+	return int(math.Round(seconds)), nil
+}
+
+// FormatDuration renders seconds in Jira's duration format, e.g. "1d 2h 30m".
+func FormatDuration(seconds int) string {
+	if seconds < 60 {
+		return "0m"
+	}
+	units := []struct {
+		suffix string
+		size   int
+	}{
+		{"w", DaysPerWeek * HoursPerDay * 3600},
+		{"d", HoursPerDay * 3600},
+		{"h", 3600},
+		{"m", 60},
+	}
+	var parts []string
+	for _, u := range units {
+		if n := seconds / u.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.suffix))
+			seconds -= n * u.size
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatTime formats t as a Jira timestamp.
+func FormatTime(t time.Time) string {
+	return t.Format(TimeLayout)
+}
@@ -0,0 +1,80 @@
+package jira
+
+import "testing"
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"1w 2d", (5 + 2) * 8 * 3600, false},
+		{"2d 4h", 20 * 3600, false},
+		{"1d4h", 12 * 3600, false},
+		{"1h30m", 5400, false},
+		{"90m", 5400, false},
+		{"1.5h", 5400, false},
+		{" 1H 30M ", 5400, false},
+		{"90", 5400, false},
+		{"1", 60, false},
+		{"1h 90", 9000, false},
+		{"0.5m", 0, true},
+		{"0", 0, true},
+		{"0m", 0, true},
+		{"", 0, true},
+		{"30s", 0, true},
+		{"2y", 0, true},
+		{"1h 30x", 0, true},
+		{"h", 0, true},
+		{"-1h", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDuration(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseDuration(%q) = %d, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseDuration(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "0m"},
+		{59, "0m"},
+		{60, "1m"},
+		{90, "1m"},
+		{5400, "1h 30m"},
+		{8 * 3600, "1d"},
+		{20 * 3600, "2d 4h"},
+		{7 * 8 * 3600, "1w 2d"},
+		{40*3600 + 60, "1w 1m"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.seconds); got != tt.want {
+			t.Errorf("FormatDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+// TestDurationRoundTrip formats parsed durations back to Jira's form.
+func TestDurationRoundTrip(t *testing.T) {
+	for _, s := range []string{"1w 2d", "2d 4h", "1h 30m", "45m", "3w"} {
+		seconds, err := ParseDuration(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := FormatDuration(seconds); got != s {
+			t.Errorf("round trip of %q\n got: %q", s, got)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package keychain
+
+import (
+	"fmt"
+	"os"
+)
+
+// tokenEnvVar holds a token supplied through the environment, typically by CI.
+const tokenEnvVar = "JIRA_TOKEN"
+
+// envStore reads the token from JIRA_TOKEN. It is read-only and applies to
+// every account.
+type envStore struct{}
+
+func (envStore) Set(account, token string) error {
+	return fmt.Errorf("the env backend is read-only: export %s in your shell instead", tokenEnvVar)
+}
+
+func (envStore) Get(account string) (string, error) {
+	token := os.Getenv(tokenEnvVar)
+	if token == "" {
+		return "", fmt.Errorf("%s is not set", tokenEnvVar)
+	}
+	return token, nil
+}
+
+func (envStore) Delete(account string) error {
+	return fmt.Errorf("the env backend is read-only: unset %s in your shell instead", tokenEnvVar)
+}
@@ -0,0 +1,161 @@
+package keychain
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// passphraseEnvVar holds the passphrase that protects the encrypted file backend.
+const passphraseEnvVar = "JIRA_CLI_PASSPHRASE"
+
+const (
+	fileMagic        = "JCLI1"
+	fileSaltSize     = 16
+	fileKDFIteration = 600_000
+)
+
+// encryptedFile stores tokens in a single AES-256-GCM encrypted file, keyed by
+// a passphrase from JIRA_CLI_PASSPHRASE. The file holds a JSON map of account
+// to token, laid out as magic | salt | nonce | ciphertext.
+type encryptedFile struct {
+	path string
+}
+
+func (f encryptedFile) Set(account, token string) error {
+	tokens, err := f.load()
+	if err != nil {
+		return err
+	}
+	tokens[account] = token
+	return f.save(tokens)
+}
+
+func (f encryptedFile) Get(account string) (string, error) {
+	tokens, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	token, ok := tokens[account]
+	if !ok {
+		return "", fmt.Errorf("no PAT for %s in %s (have you run 'jira auth store'?)", account, f.path)
+	}
+	return token, nil
+}
+
+func (f encryptedFile) Delete(account string) error {
+	tokens, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[account]; !ok {
+		return fmt.Errorf("no PAT for %s in %s", account, f.path)
+	}
+	delete(tokens, account)
+	return f.save(tokens)
+}
+
+// load decrypts the credentials file. A missing file yields an empty map.
+func (f encryptedFile) load() (map[string]string, error) {
+	tokens := map[string]string{}
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading credentials file: %w", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte(fileMagic)) {
+		return nil, fmt.Errorf("%s is not a jira-cli credentials file", f.path)
+	}
+	data = data[len(fileMagic):]
+	if len(data) < fileSaltSize {
+		return nil, fmt.Errorf("%s is truncated", f.path)
+	}
+	salt, data := data[:fileSaltSize], data[fileSaltSize:]
+
+	gcm, err := fileCipher(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%s is truncated", f.path)
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(fileMagic))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s: wrong %s?", f.path, passphraseEnvVar)
+	}
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("decoding credentials file: %w", err)
+	}
+	return tokens, nil
+}
+
+// save encrypts tokens with a fresh salt and nonce and writes them atomically.
+func (f encryptedFile) save(tokens map[string]string) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("encoding credentials: %w", err)
+	}
+
+	salt := make([]byte, fileSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generating salt: %w", err)
+	}
+	gcm, err := fileCipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating nonce: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fileMagic)
+	buf.Write(salt)
+	buf.Write(nonce)
+	buf.Write(gcm.Seal(nil, nonce, plaintext, []byte(fileMagic)))
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing credentials file: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("writing credentials file: %w", err)
+	}
+	return nil
+}
+
+// fileCipher derives the AES-256-GCM cipher for the given salt from the passphrase.
+func fileCipher(salt []byte) (cipher.AEAD, error) {
+	passphrase := os.Getenv(passphraseEnvVar)
+	if passphrase == "" {
+		return nil, fmt.Errorf("the file backend requires %s to be set", passphraseEnvVar)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, fileKDFIteration, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,104 @@
+package keychain
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// helperStore delegates to an external credential helper speaking git's
+// credential protocol: the helper is invoked with "get", "store" or "erase"
+// and exchanges key=value lines, terminated by a blank line, on stdin/stdout.
+//
+// The command follows git's credential.helper rules: a value starting with
+// "!" is run as a shell snippet, an absolute path is run as-is, and any other
+// name is expanded to jira-credential-<name> on PATH.
+type helperStore struct {
+	command string
+}
+
+// helperPrefix is prepended to bare helper names, like git-credential-.
+const helperPrefix = "jira-credential-"
+
+func (h helperStore) Set(account, token string) error {
+	attrs := helperAttributes(account)
+	attrs = append(attrs, "password="+token)
+	if _, err := h.call("store", attrs); err != nil {
+		return fmt.Errorf("failed to store PAT with credential helper: %w", err)
+	}
+	return nil
+}
+
+func (h helperStore) Get(account string) (string, error) {
+	reply, err := h.call("get", helperAttributes(account))
+	if err != nil {
+		return "", fmt.Errorf("failed to read PAT from credential helper: %w", err)
+	}
+	if reply["password"] == "" {
+		return "", fmt.Errorf("credential helper %q returned no password for %s", h.command, account)
+	}
+	return reply["password"], nil
+}
+
+func (h helperStore) Delete(account string) error {
+	if _, err := h.call("erase", helperAttributes(account)); err != nil {
+		return fmt.Errorf("failed to delete PAT with credential helper: %w", err)
+	}
+	return nil
+}
+
+// call runs the helper with the given action, writes attrs to its stdin and
+// parses the key=value lines it prints.
+func (h helperStore) call(action string, attrs []string) (map[string]string, error) {
+	cmd := exec.Command("sh", "-c", h.shellCommand()+" "+action)
+	cmd.Stdin = strings.NewReader(strings.Join(attrs, "\n") + "\n\n")
+	cmd.Stderr = os.Stderr // helpers may prompt or report on stderr
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", h.command, action, err)
+	}
+
+	reply := map[string]string{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			reply[key] = value
+		}
+	}
+	return reply, scanner.Err()
+}
+
+// shellCommand expands the configured helper into a shell command line.
+func (h helperStore) shellCommand() string {
+	switch {
+	case strings.HasPrefix(h.command, "!"):
+		return h.command[1:]
+	case filepath.IsAbs(h.command):
+		return h.command
+	default:
+		return helperPrefix + h.command
+	}
+}
+
+// helperAttributes describes a Jira URL as protocol/host/path attributes.
+func helperAttributes(account string) []string {
+	u, err := url.Parse(account)
+	if err != nil || u.Host == "" {
+		return []string{"url=" + account}
+	}
+	attrs := []string{"protocol=" + u.Scheme, "host=" + u.Host}
+	if p := strings.Trim(u.Path, "/"); p != "" {
+		attrs = append(attrs, "path="+p)
+	}
+	return attrs
+}
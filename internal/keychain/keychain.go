@@ -1,8 +1,12 @@
 package keychain
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 )
 
@@ -10,48 +14,169 @@ const (
 	serviceName = "jira-cli"
 )
 
-// StorePAT stores a Personal Access Token in the macOS Keychain.
-func StorePAT(account, token string) error {
-	// First try to delete any existing entry (ignore errors if it doesn't exist)
-	_ = exec.Command("security", "delete-generic-password",
-		"-s", serviceName,
-		"-a", account,
-	).Run()
-
-	cmd := exec.Command("security", "add-generic-password",
-		"-s", serviceName,
-		"-a", account,
-		"-w", token,
-		"-U",
-	)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to store PAT in keychain: %s: %w", strings.TrimSpace(string(output)), err)
+// Backend names accepted by SetBackend and Open.
+const (
+	BackendAuto          = "auto"
+	BackendMacOS         = "keychain"
+	BackendSecretService = "secret-service"
+	BackendPass          = "pass"
+	BackendFile          = "file"
+	BackendEnv           = "env"
+)
+
+// Store persists Personal Access Tokens keyed by account (the Jira base URL).
+// Other auth methods store their secret the same way: a password, API token,
+// session cookie or OAuth access token.
+type Store interface {
+	Set(account, token string) error
+	Get(account string) (string, error)
+	Delete(account string) error
+}
+
+// backend is the backend used by StorePAT, GetPAT and DeletePAT.
+var backend = BackendAuto
+
+// helperCommand, when set, replaces backend with an external credential helper.
+var helperCommand string
+
+// fileDir is the directory of the encrypted file used by the file backend.
+var fileDir string
+
+// SetBackend selects the backend used by StorePAT, GetPAT and DeletePAT.
+func SetBackend(name string) error {
+	if name == "" {
+		name = BackendAuto
 	}
+	if _, err := Open(name); err != nil {
+		return err
+	}
+	backend = name
 	return nil
 }
 
-// GetPAT retrieves a Personal Access Token from the macOS Keychain.
+// SetFileDir sets the directory of the encrypted credentials file used by
+// the file backend, normally the jira-cli config directory.
+func SetFileDir(dir string) {
+	fileDir = dir
+}
+
+// ValidBackend checks a backend name for SetBackend.
+func ValidBackend(name string) error {
+	switch name {
+	case BackendAuto, BackendMacOS, BackendSecretService, BackendPass, BackendFile, BackendEnv:
+		return nil
+	}
+	return fmt.Errorf("unknown auth backend: %q (use auto, keychain, secret-service, pass, file, or env)", name)
+}
+
+// SetHelper routes StorePAT, GetPAT and DeletePAT through an external
+// credential helper instead of a built-in backend. An empty command clears it.
+func SetHelper(command string) {
+	helperCommand = command
+}
+
+// Backend returns the name of the backend in use, resolving "auto".
+func Backend() string {
+	if helperCommand != "" {
+		return "credential helper " + helperCommand
+	}
+	if backend == BackendAuto {
+		return Detect()
+	}
+	return backend
+}
+
+// Detect picks a backend for the current environment: JIRA_TOKEN if set,
+// the macOS Keychain on macOS, then the Secret Service, pass, and finally
+// the encrypted file.
+func Detect() string {
+	if os.Getenv(tokenEnvVar) != "" {
+		return BackendEnv
+	}
+	if runtime.GOOS == "darwin" {
+		return BackendMacOS
+	}
+	if _, err := exec.LookPath("secret-tool"); err == nil && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != "" {
+		return BackendSecretService
+	}
+	if _, err := exec.LookPath("pass"); err == nil && passStoreExists() {
+		return BackendPass
+	}
+	return BackendFile
+}
+
+// Open returns the Store for the named backend.
+func Open(name string) (Store, error) {
+	if name == "" || name == BackendAuto {
+		name = Detect()
+	}
+	switch name {
+	case BackendMacOS:
+		return macOSKeychain{}, nil
+	case BackendSecretService:
+		return secretService{}, nil
+	case BackendPass:
+		return passStore{}, nil
+	case BackendFile:
+		return encryptedFile{path: filepath.Join(fileDir, "credentials.enc")}, nil
+	case BackendEnv:
+		return envStore{}, nil
+	default:
+		return nil, ValidBackend(name)
+	}
+}
+
+// selected returns the store used by StorePAT, GetPAT and DeletePAT.
+func selected() (Store, error) {
+	if helperCommand != "" {
+		return helperStore{command: helperCommand}, nil
+	}
+	return Open(backend)
+}
+
+// StorePAT stores a Personal Access Token in the selected backend.
+func StorePAT(account, token string) error {
+	s, err := selected()
+	if err != nil {
+		return err
+	}
+	return s.Set(account, token)
+}
+
+// GetPAT retrieves a Personal Access Token from the selected backend.
 func GetPAT(account string) (string, error) {
-	cmd := exec.Command("security", "find-generic-password",
-		"-s", serviceName,
-		"-a", account,
-		"-w",
-	)
-	output, err := cmd.CombinedOutput()
+	s, err := selected()
 	if err != nil {
-		return "", fmt.Errorf("failed to read PAT from keychain (have you run 'jira auth store'?): %w", err)
+		return "", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	return s.Get(account)
 }
 
-// DeletePAT removes a Personal Access Token from the macOS Keychain.
+// DeletePAT removes a Personal Access Token from the selected backend.
 func DeletePAT(account string) error {
-	cmd := exec.Command("security", "delete-generic-password",
-		"-s", serviceName,
-		"-a", account,
-	)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to delete PAT from keychain: %s: %w", strings.TrimSpace(string(output)), err)
+	s, err := selected()
+	if err != nil {
+		return err
 	}
-	return nil
+	return s.Delete(account)
+}
+
+// run executes an external command with optional stdin and returns its trimmed
+// standard output. Standard error, where tools print warnings and diagnostics,
+// is kept out of the output and only included in the returned error.
+func run(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w", msg, err)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
 }
@@ -0,0 +1,51 @@
+package keychain
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// macOSKeychain stores tokens in the macOS Keychain via the security binary.
+type macOSKeychain struct{}
+
+func (macOSKeychain) Set(account, token string) error {
+	// First try to delete any existing entry (ignore errors if it doesn't exist)
+	_ = exec.Command("security", "delete-generic-password",
+		"-s", serviceName,
+		"-a", account,
+	).Run()
+
+	_, err := run("", "security", "add-generic-password",
+		"-s", serviceName,
+		"-a", account,
+		"-w", token,
+		"-U",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store PAT in keychain: %w", err)
+	}
+	return nil
+}
+
+func (macOSKeychain) Get(account string) (string, error) {
+	output, err := run("", "security", "find-generic-password",
+		"-s", serviceName,
+		"-a", account,
+		"-w",
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PAT from keychain (have you run 'jira auth store'?): %w", err)
+	}
+	return output, nil
+}
+
+func (macOSKeychain) Delete(account string) error {
+	_, err := run("", "security", "delete-generic-password",
+		"-s", serviceName,
+		"-a", account,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete PAT from keychain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package keychain
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// passStore stores tokens in pass, the standard Unix password manager.
+// Each account becomes an entry named jira-cli/<host>[_<path>].
+type passStore struct{}
+
+func (passStore) Set(account, token string) error {
+	_, err := run(token+"\n", "pass", "insert", "--multiline", "--force", passEntry(account))
+	if err != nil {
+		return fmt.Errorf("failed to store PAT in pass: %w", err)
+	}
+	return nil
+}
+
+func (passStore) Get(account string) (string, error) {
+	output, err := run("", "pass", "show", passEntry(account))
+	if err != nil {
+		return "", fmt.Errorf("failed to read PAT from pass (have you run 'jira auth store'?): %w", err)
+	}
+	// pass convention: the secret is the first line, metadata may follow.
+	token, _, _ := strings.Cut(output, "\n")
+	return strings.TrimSpace(token), nil
+}
+
+func (passStore) Delete(account string) error {
+	_, err := run("", "pass", "rm", "--force", passEntry(account))
+	if err != nil {
+		return fmt.Errorf("failed to delete PAT from pass: %w", err)
+	}
+	return nil
+}
+
+// passEntry converts a Jira URL into a pass entry name.
+func passEntry(account string) string {
+	name := account
+	if u, err := url.Parse(account); err == nil && u.Host != "" {
+		name = u.Host + strings.TrimSuffix(u.Path, "/")
+	}
+	return serviceName + "/" + strings.ReplaceAll(name, "/", "_")
+}
+
+// passStoreExists reports whether a pass password store has been initialised.
+func passStoreExists() bool {
+	dir := os.Getenv("PASSWORD_STORE_DIR")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		dir = filepath.Join(home, ".password-store")
+	}
+	_, err := os.Stat(filepath.Join(dir, ".gpg-id"))
+	return err == nil
+}
@@ -0,0 +1,46 @@
+package keychain
+
+import (
+	"fmt"
+)
+
+// secretService stores tokens in the freedesktop Secret Service (GNOME Keyring,
+// KWallet) over D-Bus, using the secret-tool binary from libsecret.
+type secretService struct{}
+
+func (secretService) Set(account, token string) error {
+	_, err := run(token, "secret-tool", "store",
+		"--label", fmt.Sprintf("%s (%s)", serviceName, account),
+		"service", serviceName,
+		"account", account,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store PAT in secret service: %w", err)
+	}
+	return nil
+}
+
+func (secretService) Get(account string) (string, error) {
+	output, err := run("", "secret-tool", "lookup",
+		"service", serviceName,
+		"account", account,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PAT from secret service (have you run 'jira auth store'?): %w", err)
+	}
+	if output == "" {
+		return "", fmt.Errorf("no PAT in secret service for %s (have you run 'jira auth store'?)", account)
+	}
+	return output, nil
+}
+
+func (secretService) Delete(account string) error {
+	_, err := run("", "secret-tool", "clear",
+		"service", serviceName,
+		"account", account,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete PAT from secret service: %w", err)
+	}
+	return nil
+}
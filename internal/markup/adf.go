@@ -0,0 +1,621 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ADFNode is a node of an Atlassian Document Format document, the rich text
+// format used by Jira Cloud's REST API v3.
+type ADFNode struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []ADFMark              `json:"marks,omitempty"`
+	Content []*ADFNode             `json:"content,omitempty"`
+}
+
+// ADFMark is a text formatting mark such as strong, em or link.
+type ADFMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// attr returns a string attribute of n, or "".
+func (n *ADFNode) attr(key string) string {
+	switch v := n.Attrs[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	return ""
+}
+
+// panelLabels names the ADF panel types rendered as labelled quotes.
+var panelLabels = map[string]string{
+	"info":    "Info",
+	"note":    "Note",
+	"warning": "Warning",
+	"success": "Success",
+	"error":   "Error",
+	"tip":     "Tip",
+}
+
+// ADFToMarkdown converts an ADF document to Markdown. Nodes it does not
+// know are rendered through their content.
+func ADFToMarkdown(doc *ADFNode) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimRight(adfBlocks(doc.Content), "\n")
+}
+
+// adfBlocks renders block nodes separated by blank lines.
+func adfBlocks(nodes []*ADFNode) string {
+	var blocks []string
+	for _, n := range nodes {
+		if s := adfBlock(n); s != "" {
+			blocks = append(blocks, s)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func adfBlock(n *ADFNode) string {
+	switch n.Type {
+	case "paragraph":
+		return adfInline(n.Content)
+	case "heading":
+		level, _ := strconv.Atoi(n.attr("level"))
+		level = min(max(level, 1), 6)
+		return strings.Repeat("#", level) + " " + adfInline(n.Content)
+	case "bulletList", "orderedList", "taskList":
+		return adfList(n)
+	case "codeBlock":
+		text := adfPlain(n.Content)
+		fence := codeFence(text)
+		return fence + n.attr("language") + "\n" + text + "\n" + fence
+	case "blockquote":
+		return quoteLines(adfBlocks(n.Content))
+	case "panel":
+		body := adfBlocks(n.Content)
+		if label, ok := panelLabels[n.attr("panelType")]; ok {
+			body = "**" + label + "**\n\n" + body
+		}
+		return quoteLines(body)
+	case "expand", "nestedExpand":
+		body := adfBlocks(n.Content)
+		if title := n.attr("title"); title != "" {
+			body = "**" + title + "**\n\n" + body
+		}
+		return body
+	case "rule":
+		return "---"
+	case "table":
+		return adfTable(n)
+	case "mediaSingle", "mediaGroup":
+		var media []string
+		for _, c := range n.Content {
+			media = append(media, adfMedia(c))
+		}
+		return strings.Join(media, " ")
+	case "media":
+		return adfMedia(n)
+	case "blockCard", "embedCard":
+		return "<" + n.attr("url") + ">"
+	}
+	if len(n.Content) > 0 && n.Content[0].isBlock() {
+		return adfBlocks(n.Content)
+	}
+	return adfInline([]*ADFNode{n})
+}
+
+func (n *ADFNode) isBlock() bool {
+	switch n.Type {
+	case "text", "hardBreak", "mention", "emoji", "inlineCard", "status", "date", "placeholder":
+		return false
+	}
+	return true
+}
+
+// quoteLines prefixes every line with a Markdown quote marker.
+func quoteLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func adfList(n *ADFNode) string {
+	var items []string
+	for i, item := range n.Content {
+		marker := "- "
+		switch {
+		case n.Type == "orderedList":
+			marker = fmt.Sprintf("%d. ", i+1)
+		case item.Type == "taskItem":
+			marker = "- [ ] "
+			if item.attr("state") == "DONE" {
+				marker = "- [x] "
+			}
+		}
+
+		var body string
+		if item.Type == "taskItem" {
+			body = adfInline(item.Content)
+		} else {
+			// Items are tight: their blocks are not separated by blank lines.
+			var blocks []string
+			for _, c := range item.Content {
+				if s := adfBlock(c); s != "" {
+					blocks = append(blocks, s)
+				}
+			}
+			body = strings.Join(blocks, "\n")
+		}
+
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(body, "\n")
+		for j := range lines {
+			if j == 0 {
+				lines[j] = marker + lines[j]
+			} else if lines[j] != "" {
+				lines[j] = indent + lines[j]
+			}
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+func adfTable(n *ADFNode) string {
+	var rows [][]string
+	header := false
+	width := 0
+	for i, row := range n.Content {
+		var cells []string
+		for _, cell := range row.Content {
+			if i == 0 && cell.Type == "tableHeader" {
+				header = true
+			}
+			text := adfBlocks(cell.Content)
+			text = strings.ReplaceAll(strings.ReplaceAll(text, "\n\n", "<br>"), "\n", "<br>")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		}
+		rows = append(rows, cells)
+		width = max(width, len(cells))
+	}
+	if width == 0 {
+		return ""
+	}
+
+	line := func(cells []string) string {
+		for len(cells) < width {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	separator := make([]string, width)
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	var out []string
+	if header {
+		out = append(out, line(rows[0]), line(separator))
+		rows = rows[1:]
+	} else {
+		out = append(out, line(make([]string, width)), line(separator))
+	}
+	for _, r := range rows {
+		out = append(out, line(r))
+	}
+	return strings.Join(out, "\n")
+}
+
+func adfMedia(n *ADFNode) string {
+	name := n.attr("alt")
+	if name == "" {
+		name = n.attr("id")
+	}
+	if n.attr("type") == "external" {
+		return fmt.Sprintf("![%s](%s)", name, n.attr("url"))
+	}
+	return fmt.Sprintf("![%s](%s)", name, name)
+}
+
+// adfPlain returns the text of inline nodes without formatting.
+func adfPlain(nodes []*ADFNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		if n.Type == "hardBreak" {
+			b.WriteString("\n")
+		}
+		b.WriteString(n.Text)
+	}
+	return b.String()
+}
+
+// adfInline renders inline nodes with their marks.
+func adfInline(nodes []*ADFNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "text":
+			b.WriteString(applyMarks(n.Text, n.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			if text := n.attr("text"); text != "" {
+				b.WriteString(text)
+			} else {
+				b.WriteString("@" + n.attr("id"))
+			}
+		case "emoji":
+			if text := n.attr("text"); text != "" {
+				b.WriteString(text)
+			} else {
+				b.WriteString(n.attr("shortName"))
+			}
+		case "inlineCard":
+			b.WriteString("<" + n.attr("url") + ">")
+		case "status":
+			b.WriteString("`" + n.attr("text") + "`")
+		case "date":
+			if ms, err := strconv.ParseInt(n.attr("timestamp"), 10, 64); err == nil {
+				b.WriteString(time.UnixMilli(ms).UTC().Format("2006-01-02"))
+			}
+		default:
+			b.WriteString(adfInline(n.Content))
+		}
+	}
+	return b.String()
+}
+
+func applyMarks(text string, marks []ADFMark) string {
+	var href string
+	for _, m := range marks {
+		if m.Type == "code" {
+			text = codeSpan(text)
+		}
+	}
+	for _, m := range marks {
+		switch m.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "underline":
+			text = "<ins>" + text + "</ins>"
+		case "subsup":
+			tag := "sub"
+			if (&ADFNode{Attrs: m.Attrs}).attr("type") == "sup" {
+				tag = "sup"
+			}
+			text = "<" + tag + ">" + text + "</" + tag + ">"
+		case "link":
+			href = (&ADFNode{Attrs: m.Attrs}).attr("href")
+		}
+	}
+	if href != "" && href != text {
+		text = "[" + text + "](" + href + ")"
+	}
+	return text
+}
+
+// MarkdownToADF converts Markdown to an ADF document. It handles headings,
+// paragraphs, fenced code, quotes, nested lists, tables, horizontal rules,
+// emphasis, strikethrough, code spans, links, line breaks and @mentions of
+// Jira Cloud account IDs.
+func MarkdownToADF(md string) *ADFNode {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	content := adfParseBlocks(lines)
+	if len(content) == 0 {
+		content = []*ADFNode{{Type: "paragraph"}}
+	}
+	return &ADFNode{Type: "doc", Version: 1, Content: content}
+}
+
+func adfParseBlocks(lines []string) []*ADFNode {
+	var blocks []*ADFNode
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, &ADFNode{Type: "paragraph", Content: parseADFInline(strings.Join(para, "\n"), nil)})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case mdFence.MatchString(trimmed):
+			flush()
+			m := mdFence.FindStringSubmatch(trimmed)
+			j := i + 1
+			for j < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[j]), m[1]) {
+				j++
+			}
+			code := &ADFNode{Type: "codeBlock"}
+			if m[2] != "" {
+				code.Attrs = map[string]interface{}{"language": m[2]}
+			}
+			if text := strings.Join(lines[i+1:min(j, len(lines))], "\n"); text != "" {
+				code.Content = []*ADFNode{{Type: "text", Text: text}}
+			}
+			blocks = append(blocks, code)
+			i = j
+		case mdHeading.MatchString(trimmed):
+			flush()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			blocks = append(blocks, &ADFNode{
+				Type:    "heading",
+				Attrs:   map[string]interface{}{"level": len(m[1])},
+				Content: parseADFInline(m[2], nil),
+			})
+		case mdRule.MatchString(line) && len(strings.ReplaceAll(trimmed, " ", "")) >= 3:
+			flush()
+			blocks = append(blocks, &ADFNode{Type: "rule"})
+		case mdQuote.MatchString(line):
+			flush()
+			var body []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				body = append(body, mdQuote.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			blocks = append(blocks, &ADFNode{Type: "blockquote", Content: adfParseBlocks(body)})
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && mdTableDelim.MatchString(lines[i+1]):
+			flush()
+			j := i + 2
+			for j < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[j]), "|") {
+				j++
+			}
+			blocks = append(blocks, adfParseTable(lines[i], lines[i+2:j]))
+			i = j - 1
+		case mdListItem.MatchString(line):
+			flush()
+			var list *ADFNode
+			list, i = adfParseList(lines, i)
+			blocks = append(blocks, list)
+			i--
+		default:
+			para = append(para, strings.TrimSpace(line))
+		}
+	}
+	flush()
+	return blocks
+}
+
+// adfParseList parses the list starting at line i and returns it with the
+// index of the first line after it.
+func adfParseList(lines []string, i int) (*ADFNode, int) {
+	first := mdListItem.FindStringSubmatch(lines[i])
+	indent := len(first[1])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+	list := &ADFNode{Type: "bulletList"}
+	if ordered {
+		list.Type = "orderedList"
+	}
+
+	for i < len(lines) {
+		m := mdListItem.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) != indent || (m[2][0] >= '0' && m[2][0] <= '9') != ordered {
+			break
+		}
+		// The item runs until the next line at or left of the marker that
+		// is not a blank line followed by an indented one.
+		body := []string{m[3]}
+		contentIndent := len(m[1]) + len(m[2]) + 1
+		j := i + 1
+		for j < len(lines) {
+			l := lines[j]
+			if strings.TrimSpace(l) == "" {
+				if j+1 < len(lines) && leadingSpaces(lines[j+1]) > indent {
+					body = append(body, "")
+					j++
+					continue
+				}
+				break
+			}
+			if leadingSpaces(l) <= indent {
+				break
+			}
+			body = append(body, l[min(contentIndent, leadingSpaces(l)):])
+			j++
+		}
+		list.Content = append(list.Content, &ADFNode{Type: "listItem", Content: adfParseBlocks(body)})
+		i = j
+	}
+	return list, i
+}
+
+func leadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
+
+func adfParseTable(header string, rows []string) *ADFNode {
+	table := &ADFNode{Type: "table"}
+	row := func(line, cellType string) *ADFNode {
+		r := &ADFNode{Type: "tableRow"}
+		for _, cell := range markdownCells(line) {
+			r.Content = append(r.Content, &ADFNode{
+				Type:    cellType,
+				Content: []*ADFNode{{Type: "paragraph", Content: parseADFInline(cell, nil)}},
+			})
+		}
+		return r
+	}
+	if strings.TrimSpace(strings.Join(markdownCells(header), "")) != "" {
+		table.Content = append(table.Content, row(header, "tableHeader"))
+	}
+	for _, r := range rows {
+		table.Content = append(table.Content, row(r, "tableCell"))
+	}
+	return table
+}
+
+// markdownCells splits a Markdown table row on unescaped pipes.
+func markdownCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// adfInlinePattern matches the inline Markdown constructs, tried leftmost
+// first. The submatch that is set identifies the construct.
+var adfInlinePattern = regexp.MustCompile(strings.Join([]string{
+	`\\([!-/:-@\[-` + "`" + `{-~])`,                             // 1: escaped character
+	"(`+)(.+?)(`+)",                                             // 2-4: code span
+	`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`,                 // 5-6: image
+	`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`,                  // 7-8: link
+	`<((?:https?|ftp|mailto):[^>\s]+)>`,                         // 9: autolink
+	`(?:https?|ftp)://[^\s\[\]<>"]+`,                            // bare URL (no group)
+	`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`,                          // 10-12: strong
+	`(\*)(\S(?:[^*]*?\S)?)(\*)`,                                 // 13-15: em
+	`~~(\S(?:.*?\S)?)~~`,                                        // 16: strike
+	`<(ins|u|sub|sup)>(.*?)</(ins|u|sub|sup)>`,                  // 17-19: underline, sub/sup
+	`@([A-Za-z0-9][A-Za-z0-9._-]*:[A-Za-z0-9._:-]*[A-Za-z0-9])`, // 20: account ID mention
+	`<br\s*/?>|\n`,                                              // hard break (no group)
+}, "|"))
+
+// parseADFInline parses inline Markdown into text nodes carrying marks.
+func parseADFInline(s string, marks []ADFMark) []*ADFNode {
+	var nodes []*ADFNode
+	text := func(t string, extra ...ADFMark) {
+		if t == "" {
+			return
+		}
+		m := append(append([]ADFMark(nil), marks...), extra...)
+		if len(m) == 0 {
+			m = nil
+		}
+		if n := len(nodes); n > 0 && nodes[n-1].Type == "text" && sameMarks(nodes[n-1].Marks, m) {
+			nodes[n-1].Text += t
+			return
+		}
+		nodes = append(nodes, &ADFNode{Type: "text", Text: t, Marks: m})
+	}
+	with := func(inner string, mark ADFMark) {
+		nodes = append(nodes, parseADFInline(inner, append(append([]ADFMark(nil), marks...), mark))...)
+	}
+
+	for s != "" {
+		loc := adfInlinePattern.FindStringSubmatchIndex(s)
+		if loc == nil {
+			text(s)
+			break
+		}
+		text(s[:loc[0]])
+		match := s[loc[0]:loc[1]]
+		group := func(i int) string {
+			if loc[2*i] < 0 {
+				return ""
+			}
+			return s[loc[2*i]:loc[2*i+1]]
+		}
+		set := func(i int) bool { return loc[2*i] >= 0 }
+
+		switch {
+		case set(1):
+			text(group(1))
+		case set(2):
+			if group(2) != group(4) {
+				text(match)
+			} else {
+				text(strings.TrimSpace(group(3)), ADFMark{Type: "code"})
+			}
+		case set(5) || set(6):
+			alt := group(5)
+			if alt == "" {
+				alt = group(6)
+			}
+			with(alt, ADFMark{Type: "link", Attrs: map[string]interface{}{"href": group(6)}})
+		case set(7):
+			with(group(7), ADFMark{Type: "link", Attrs: map[string]interface{}{"href": group(8)}})
+		case set(9):
+			text(group(9), ADFMark{Type: "link", Attrs: map[string]interface{}{"href": group(9)}})
+		case set(10):
+			if group(10) != group(12) {
+				text(match)
+			} else {
+				with(group(11), ADFMark{Type: "strong"})
+			}
+		case set(13):
+			if group(13) != group(15) {
+				text(match)
+			} else {
+				with(group(14), ADFMark{Type: "em"})
+			}
+		case set(16):
+			with(group(16), ADFMark{Type: "strike"})
+		case set(17):
+			switch {
+			case group(17) != group(19):
+				text(match)
+			case group(17) == "sub" || group(17) == "sup":
+				with(group(18), ADFMark{Type: "subsup", Attrs: map[string]interface{}{"type": group(17)}})
+			default:
+				with(group(18), ADFMark{Type: "underline"})
+			}
+		case set(20):
+			if loc[0] > 0 && !strings.ContainsRune(" \t(", rune(s[loc[0]-1])) {
+				text(match)
+			} else {
+				nodes = append(nodes, &ADFNode{Type: "mention", Attrs: map[string]interface{}{"id": group(20)}})
+			}
+		case strings.HasPrefix(match, "<br") || match == "\n":
+			nodes = append(nodes, &ADFNode{Type: "hardBreak"})
+		default:
+			// Bare URL.
+			text(match, ADFMark{Type: "link", Attrs: map[string]interface{}{"href": match}})
+		}
+		s = s[loc[1]:]
+	}
+	return nodes
+}
+
+func sameMarks(a, b []ADFMark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || fmt.Sprint(a[i].Attrs) != fmt.Sprint(b[i].Attrs) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package markup
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestADFToMarkdown(t *testing.T) {
+	tests := []struct {
+		name, adf, want string
+	}{
+		{"heading and paragraph",
+			`[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Goal"}]},
+			  {"type":"paragraph","content":[{"type":"text","text":"Make it work"}]}]`,
+			"## Goal\n\nMake it work"},
+		{"marks",
+			`[{"type":"paragraph","content":[
+			  {"type":"text","text":"b","marks":[{"type":"strong"}]},{"type":"text","text":" "},
+			  {"type":"text","text":"i","marks":[{"type":"em"}]},{"type":"text","text":" "},
+			  {"type":"text","text":"s","marks":[{"type":"strike"}]},{"type":"text","text":" "},
+			  {"type":"text","text":"u","marks":[{"type":"underline"}]},{"type":"text","text":" "},
+			  {"type":"text","text":"x","marks":[{"type":"code"}]},{"type":"text","text":" "},
+			  {"type":"text","text":"2","marks":[{"type":"subsup","attrs":{"type":"sup"}}]},{"type":"text","text":" "},
+			  {"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://x/y"}},{"type":"strong"}]}]}]`,
+			"**b** *i* ~~s~~ <ins>u</ins> `x` <sup>2</sup> [**docs**](https://x/y)"},
+		{"mentions and hard break",
+			`[{"type":"paragraph","content":[
+			  {"type":"mention","attrs":{"id":"557058:abc","text":"@Alice Smith"}},{"type":"hardBreak"},
+			  {"type":"mention","attrs":{"id":"557058:def"}},{"type":"text","text":" about -v- and {x}"}]}]`,
+			"@Alice Smith\n@557058:def about -v- and {x}"},
+		{"nested lists",
+			`[{"type":"bulletList","content":[{"type":"listItem","content":[
+			  {"type":"paragraph","content":[{"type":"text","text":"one"}]},
+			  {"type":"orderedList","content":[
+			    {"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]}]},
+			    {"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"b"}]}]}]}]}]}]`,
+			"- one\n  1. a\n  2. b"},
+		{"task list",
+			`[{"type":"taskList","content":[
+			  {"type":"taskItem","attrs":{"state":"DONE"},"content":[{"type":"text","text":"done"}]},
+			  {"type":"taskItem","attrs":{"state":"TODO"},"content":[{"type":"text","text":"todo"}]}]}]`,
+			"- [x] done\n- [ ] todo"},
+		{"code block",
+			`[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"# not a heading\n` + "```" + `"}]}]`,
+			"````go\n# not a heading\n```\n````"},
+		{"panel",
+			`[{"type":"panel","attrs":{"panelType":"warning"},"content":[
+			  {"type":"paragraph","content":[{"type":"text","text":"careful"}]},
+			  {"type":"paragraph","content":[{"type":"text","text":"really"}]}]}]`,
+			"> **Warning**\n>\n> careful\n>\n> really"},
+		{"table with header",
+			`[{"type":"table","content":[
+			  {"type":"tableRow","content":[
+			    {"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"A"}]}]},
+			    {"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"B"}]}]}]},
+			  {"type":"tableRow","content":[
+			    {"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"a|b"}]}]},
+			    {"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]},{"type":"paragraph","content":[{"type":"text","text":"2"}]}]}]}]}]`,
+			"| A | B |\n| --- | --- |\n| a\\|b | 1<br>2 |"},
+		{"table without header",
+			`[{"type":"table","content":[{"type":"tableRow","content":[
+			  {"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]}]}]}]`,
+			"|  |\n| --- |\n| 1 |"},
+		{"quote and rule",
+			`[{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]},{"type":"rule"}]`,
+			"> quoted\n\n---"},
+		{"cards, media and status",
+			`[{"type":"paragraph","content":[{"type":"inlineCard","attrs":{"url":"https://x/1"}},{"type":"text","text":" "},
+			  {"type":"status","attrs":{"text":"DONE"}}]},
+			  {"type":"mediaSingle","content":[{"type":"media","attrs":{"type":"external","url":"https://x/a.png","alt":"a.png"}}]}]`,
+			"<https://x/1> `DONE`\n\n![a.png](https://x/a.png)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &ADFNode{Type: "doc", Version: 1}
+			if err := json.Unmarshal([]byte(tt.adf), &doc.Content); err != nil {
+				t.Fatal(err)
+			}
+			if got := ADFToMarkdown(doc); got != tt.want {
+				t.Errorf("ADFToMarkdown\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToADF(t *testing.T) {
+	tests := []struct {
+		name, md, want string
+	}{
+		{"empty", "", `[{"type":"paragraph"}]`},
+		{"heading and paragraph", "# Title\n\nSome text",
+			`[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Title"}]},` +
+				`{"type":"paragraph","content":[{"type":"text","text":"Some text"}]}]`},
+		{"marks", "**b** *i* ~~s~~ `x`",
+			`[{"type":"paragraph","content":[{"type":"text","text":"b","marks":[{"type":"strong"}]},{"type":"text","text":" "},` +
+				`{"type":"text","text":"i","marks":[{"type":"em"}]},{"type":"text","text":" "},` +
+				`{"type":"text","text":"s","marks":[{"type":"strike"}]},{"type":"text","text":" "},` +
+				`{"type":"text","text":"x","marks":[{"type":"code"}]}]}]`},
+		{"links", "[docs](https://x/y) <https://x/z> https://x/w",
+			`[{"type":"paragraph","content":[{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://x/y"}}]},{"type":"text","text":" "},` +
+				`{"type":"text","text":"https://x/z","marks":[{"type":"link","attrs":{"href":"https://x/z"}}]},{"type":"text","text":" "},` +
+				`{"type":"text","text":"https://x/w","marks":[{"type":"link","attrs":{"href":"https://x/w"}}]}]}]`},
+		{"plain text is kept", `Flag -v- and {x} \*y\*`,
+			`[{"type":"paragraph","content":[{"type":"text","text":"Flag -v- and {x} *y*"}]}]`},
+		{"mentions", "Ask @557058:abc-def, not @jdoe or me@557058:x",
+			`[{"type":"paragraph","content":[{"type":"text","text":"Ask "},{"type":"mention","attrs":{"id":"557058:abc-def"}},` +
+				`{"type":"text","text":", not @jdoe or me@557058:x"}]}]`},
+		{"nested lists", "- a\n  1. b\n- c",
+			`[{"type":"bulletList","content":[` +
+				`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]},` +
+				`{"type":"orderedList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"b"}]}]}]}]},` +
+				`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"c"}]}]}]}]`},
+		{"code", "```go\nx := *y*\n```",
+			`[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := *y*"}]}]`},
+		{"table", "| A |\n| --- |\n| 1 |",
+			`[{"type":"table","content":[` +
+				`{"type":"tableRow","content":[{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"A"}]}]}]},` +
+				`{"type":"tableRow","content":[{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]}]}]}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(MarkdownToADF(tt.md).Content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarkdownToADF(%q)\n got: %s\nwant: %s", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestADFRoundTrip converts Markdown to ADF and back.
+func TestADFRoundTrip(t *testing.T) {
+	for _, md := range []string{
+		"## Heading\n\nText with **bold**, *em*, ~~strike~~ and `code`.",
+		"- one\n  - nested\n- two\n\n1. first\n2. second",
+		"```sh\necho \"# not a heading\"\n```",
+		"| A | B |\n| --- | --- |\n| 1 | 2 |",
+		"> quoted\n\n---\n\nSee [docs](https://x/y) and https://x/z",
+		"Ask @557058:abc about -v- and {x}",
+	} {
+		if got := ADFToMarkdown(MarkdownToADF(md)); got != md {
+			t.Errorf("round trip of %q\n got: %q", md, got)
+		}
+	}
+}
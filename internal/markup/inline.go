@@ -0,0 +1,220 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	monospace = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiLink  = regexp.MustCompile(`\[([^\[\]\n]+)\]`)
+	image     = regexp.MustCompile(`(^|[\s(])!([^\s!|]+)(\|[^!\n]*)?!`)
+	bareURL   = regexp.MustCompile(`(?:https?|ftp)://[^\s\[\]<>"]+`)
+	colorTag  = regexp.MustCompile(`\{color(?::[^}]*)?\}`)
+	anchorTag = regexp.MustCompile(`\{anchor:[^}]*\}`)
+	lineBreak = regexp.MustCompile(`\\\\`)
+	protected = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// textEffects maps wiki text effect markers to their Markdown (or inline
+// HTML) equivalents, in the order they are applied.
+var textEffects = []struct{ marker, open, close string }{
+	{"*", "**", "**"},
+	{"_", "*", "*"},
+	{"-", "~~", "~~"},
+	{"+", "<ins>", "</ins>"},
+	{"??", "<cite>", "</cite>"},
+}
+
+// convertInline converts the inline markup of a single line.
+func convertInline(s string) string {
+	// Code spans, links and URLs are swapped for placeholders so that text
+	// effects are not applied inside them.
+	var saved []string
+	protect := func(md string) string {
+		saved = append(saved, md)
+		return fmt.Sprintf("\x00%d\x00", len(saved)-1)
+	}
+
+	s = monospace.ReplaceAllStringFunc(s, func(m string) string {
+		return protect(codeSpan(monospace.FindStringSubmatch(m)[1]))
+	})
+	s = unescape(s, protect)
+	s = colorTag.ReplaceAllString(s, "")
+	s = anchorTag.ReplaceAllString(s, "")
+	s = image.ReplaceAllStringFunc(s, func(m string) string {
+		sm := image.FindStringSubmatch(m)
+		return sm[1] + protect(fmt.Sprintf("![%s](%s)", imageAlt(sm[2]), sm[2]))
+	})
+	s = wikiLink.ReplaceAllStringFunc(s, func(m string) string {
+		md, ok := convertLink(wikiLink.FindStringSubmatch(m)[1])
+		if !ok {
+			return m
+		}
+		return protect(md)
+	})
+	s = bareURL.ReplaceAllStringFunc(s, protect)
+
+	for _, e := range textEffects {
+		s = replaceEffect(s, e.marker, e.open, e.close)
+	}
+	s = lineBreak.ReplaceAllString(s, "<br>")
+
+	return protected.ReplaceAllStringFunc(s, func(m string) string {
+		var i int
+		fmt.Sscanf(protected.FindStringSubmatch(m)[1], "%d", &i)
+		return saved[i]
+	})
+}
+
+// unescape replaces the backslash escapes of wiki markup, such as \{ and
+// \-, with placeholders for the literal character, escaped again where
+// Markdown needs it. Line breaks (\\) are left alone.
+func unescape(s string, protect func(string) string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		c := s[i+1]
+		switch {
+		case c == '\\':
+			b.WriteString(`\\`)
+		case c == '*' || c == '_':
+			b.WriteString(protect(`\` + string(c)))
+		case strings.IndexByte("{}[]-+^~?!|", c) >= 0:
+			b.WriteString(protect(string(c)))
+		default:
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+	}
+	return b.String()
+}
+
+// codeSpan renders s as a Markdown code span, using a longer delimiter when
+// s contains backticks.
+func codeSpan(s string) string {
+	delim := "`"
+	for strings.Contains(s, delim) {
+		delim += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return delim + " " + s + " " + delim
+	}
+	return delim + s + delim
+}
+
+// imageAlt uses the file name of an image as its alt text.
+func imageAlt(target string) string {
+	if i := strings.LastIndexAny(target, "/"); i >= 0 {
+		return target[i+1:]
+	}
+	return target
+}
+
+// convertLink converts the contents of a [...] link. It reports false for
+// brackets that are not a link, which are left as they are.
+func convertLink(content string) (string, bool) {
+	text, target, hasText := strings.Cut(content, "|")
+	if !hasText {
+		target = content
+	} else if i := strings.Index(target, "|"); i >= 0 {
+		// [text|url|tooltip]
+		target = target[:i]
+	}
+	target = strings.TrimSpace(target)
+
+	switch {
+	case strings.HasPrefix(target, "~"):
+		mention := "@" + strings.TrimPrefix(strings.TrimPrefix(target, "~"), "accountid:")
+		if hasText {
+			return text + " (" + mention + ")", true
+		}
+		return mention, true
+	case strings.HasPrefix(target, "^"):
+		name := strings.TrimPrefix(target, "^")
+		if !hasText {
+			text = name
+		}
+		return fmt.Sprintf("[%s](%s)", text, name), true
+	case strings.HasPrefix(target, "#"):
+		if !hasText {
+			text = strings.TrimPrefix(target, "#")
+		}
+		return text, true
+	case strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:"):
+		if !hasText {
+			return "<" + target + ">", true
+		}
+		return fmt.Sprintf("[%s](%s)", text, target), true
+	case hasText:
+		// [text|PROJ-123] and other targets without a scheme.
+		return fmt.Sprintf("[%s](%s)", text, target), true
+	}
+	return "", false
+}
+
+// replaceEffect replaces marker-delimited spans with open/close. Like Jira,
+// a span must start after a non-word character, must not begin or end with
+// a space, and must end before a non-word character on the same line.
+func replaceEffect(s, marker, open, close string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if !strings.HasPrefix(s[i:], marker) || !canOpen(s, i, marker) {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		start := i + len(marker)
+		end := findClose(s, start, marker)
+		if end < 0 {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		b.WriteString(open + s[start:end] + close)
+		i = end + len(marker)
+	}
+	return b.String()
+}
+
+func canOpen(s string, i int, marker string) bool {
+	if i > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		if isWord(prev) || strings.HasPrefix(marker, string(prev)) {
+			return false
+		}
+	}
+	next, _ := utf8.DecodeRuneInString(s[i+len(marker):])
+	return next != utf8.RuneError && !unicode.IsSpace(next) && !strings.HasPrefix(marker, string(next))
+}
+
+// findClose returns the index of the marker closing a span that starts at
+// start, or -1.
+func findClose(s string, start int, marker string) int {
+	for j := start + 1; j+len(marker) <= len(s); j++ {
+		if !strings.HasPrefix(s[j:], marker) {
+			continue
+		}
+		prev, _ := utf8.DecodeLastRuneInString(s[:j])
+		if unicode.IsSpace(prev) {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(s[j+len(marker):])
+		if j+len(marker) < len(s) && (isWord(next) || strings.HasPrefix(marker, string(next))) {
+			continue
+		}
+		return j
+	}
+	return -1
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
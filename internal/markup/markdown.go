@@ -0,0 +1,262 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	mdFence       = regexp.MustCompile("^(```+|~~~+)\\s*([^`\\s]*)")
+	mdListItem    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdRule        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))*\s*$`)
+	mdSetext      = regexp.MustCompile(`^\s*(=+|-+)\s*$`)
+	mdTableDelim  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdQuote       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdCodeSpan    = regexp.MustCompile("(`+)(.+?)(`+)")
+	mdImage       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdAutolink    = regexp.MustCompile(`<((?:https?|ftp|mailto):[^>\s]+)>`)
+	mdBold        = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdItalic      = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*?\S)?)\*`)
+	mdStrike      = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdMention     = regexp.MustCompile(`(^|\s)@([A-Za-z0-9][A-Za-z0-9._:-]*[A-Za-z0-9])`)
+	mdLineBreak   = regexp.MustCompile(`<br\s*/?>`)
+	mdEscape      = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+	htmlEffects   = regexp.MustCompile(`<(ins|u|cite|sup|sub)>(.*?)</(ins|u|cite|sup|sub)>`)
+	wikiSpecial   = strings.NewReplacer("{", `\{`, "[", `\[`)
+	boldMarker    = "\x01"
+	htmlEffectMap = map[string]string{"ins": "+", "u": "+", "cite": "??", "sup": "^", "sub": "~"}
+)
+
+// MarkdownToWiki converts Markdown to Jira wiki markup. It handles ATX and
+// setext headings, fenced code, quotes, nested lists, tables, horizontal
+// rules, emphasis, strikethrough, code spans, links, images and @mentions.
+// Characters that would start wiki links, macros or text effects in plain
+// text are escaped.
+func MarkdownToWiki(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	var out []string
+	// listMarkers holds the indentation and wiki marker of each open list level.
+	var listIndents []int
+	var listMarkers []byte
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if m := mdListItem.FindStringSubmatch(line); m != nil && !mdRule.MatchString(line) {
+			indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+			marker := byte('*')
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				marker = '#'
+			}
+			for len(listIndents) > 0 && listIndents[len(listIndents)-1] > indent {
+				listIndents = listIndents[:len(listIndents)-1]
+				listMarkers = listMarkers[:len(listMarkers)-1]
+			}
+			if len(listIndents) > 0 && listIndents[len(listIndents)-1] == indent {
+				listMarkers[len(listMarkers)-1] = marker
+			} else {
+				listIndents = append(listIndents, indent)
+				listMarkers = append(listMarkers, marker)
+			}
+			out = append(out, string(listMarkers)+" "+inlineToWiki(m[3]))
+			continue
+		}
+		if trimmed == "" || !strings.HasPrefix(line, " ") {
+			listIndents, listMarkers = nil, nil
+		}
+
+		switch {
+		case mdFence.MatchString(trimmed):
+			m := mdFence.FindStringSubmatch(trimmed)
+			j := i + 1
+			for j < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[j]), m[1]) {
+				j++
+			}
+			open, close := "{noformat}", "{noformat}"
+			if m[2] != "" {
+				open, close = "{code:"+m[2]+"}", "{code}"
+			}
+			out = append(out, open)
+			out = append(out, lines[i+1:min(j, len(lines))]...)
+			out = append(out, close)
+			i = j
+		case mdQuote.MatchString(line):
+			var body []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				body = append(body, mdQuote.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			out = append(out, "{quote}", MarkdownToWiki(strings.Join(body, "\n")), "{quote}")
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && mdTableDelim.MatchString(lines[i+1]):
+			j := i + 2
+			for j < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[j]), "|") {
+				j++
+			}
+			out = append(out, tableToWiki(lines[i], lines[i+2:j])...)
+			i = j - 1
+		case mdHeading.MatchString(trimmed):
+			m := mdHeading.FindStringSubmatch(trimmed)
+			out = append(out, fmt.Sprintf("h%d. %s", len(m[1]), inlineToWiki(m[2])))
+		case trimmed != "" && i+1 < len(lines) && mdSetext.MatchString(lines[i+1]) && !mdListItem.MatchString(line):
+			level := 1
+			if strings.TrimSpace(lines[i+1])[0] == '-' {
+				level = 2
+			}
+			out = append(out, fmt.Sprintf("h%d. %s", level, inlineToWiki(trimmed)))
+			i++
+		case mdRule.MatchString(line) && len(strings.ReplaceAll(trimmed, " ", "")) >= 3:
+			out = append(out, "----")
+		case trimmed == "":
+			out = append(out, "")
+		default:
+			out = append(out, inlineToWiki(strings.TrimRight(line, " ")))
+		}
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// tableToWiki converts a Markdown table. An all-empty header row, as
+// produced for wiki tables without headings, is dropped.
+func tableToWiki(header string, rows []string) []string {
+	var out []string
+	cells := tableCells(header)
+	if strings.Join(cells, "") != "" {
+		out = append(out, "||"+strings.Join(cells, "||")+"||")
+	}
+	for _, r := range rows {
+		out = append(out, "|"+strings.Join(tableCells(r), "|")+"|")
+	}
+	return out
+}
+
+// tableCells splits a Markdown table row on unescaped pipes and converts
+// each cell.
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case row[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	cells = append(cells, cell.String())
+	for i, c := range cells {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			// Jira collapses empty cells.
+			c = " "
+		}
+		cells[i] = inlineToWiki(c)
+	}
+	return cells
+}
+
+// plainEffects are the wiki text effect markers that Markdown has no
+// syntax for, so that they only occur in plain text.
+var plainEffects = []string{"-", "+", "^", "~", "??"}
+
+// escapeEffects escapes the plain-text effect markers that would start a
+// text effect in wiki markup, such as the first dash in "a -flag- b".
+func escapeEffects(s string) string {
+	for _, marker := range plainEffects {
+		var b strings.Builder
+		for i := 0; i < len(s); i++ {
+			if strings.HasPrefix(s[i:], marker) && canOpen(s, i, marker) && findClose(s, i+len(marker), marker) >= 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(s[i])
+		}
+		s = b.String()
+	}
+	return s
+}
+
+// inlineToWiki converts the inline Markdown of a single line.
+func inlineToWiki(s string) string {
+	var saved []string
+	protect := func(wiki string) string {
+		saved = append(saved, wiki)
+		return fmt.Sprintf("\x00%d\x00", len(saved)-1)
+	}
+
+	s = mdCodeSpan.ReplaceAllStringFunc(s, func(m string) string {
+		sm := mdCodeSpan.FindStringSubmatch(m)
+		if sm[1] != sm[3] {
+			return m
+		}
+		return protect("{{" + strings.TrimSpace(sm[2]) + "}}")
+	})
+	s = mdEscape.ReplaceAllStringFunc(s, func(m string) string {
+		// Characters with a meaning in wiki markup stay escaped.
+		if strings.Contains(`*_-+~^?!|[]{}\\`, m[1:]) {
+			return protect(m)
+		}
+		return protect(m[1:])
+	})
+	s = mdImage.ReplaceAllStringFunc(s, func(m string) string {
+		return protect("!" + mdImage.FindStringSubmatch(m)[2] + "!")
+	})
+	s = mdLink.ReplaceAllStringFunc(s, func(m string) string {
+		sm := mdLink.FindStringSubmatch(m)
+		if sm[1] == sm[2] {
+			return protect("[" + sm[2] + "]")
+		}
+		return protect("[" + sm[1] + "|" + sm[2] + "]")
+	})
+	s = mdAutolink.ReplaceAllStringFunc(s, func(m string) string {
+		return protect("[" + mdAutolink.FindStringSubmatch(m)[1] + "]")
+	})
+	s = bareURL.ReplaceAllStringFunc(s, protect)
+
+	s = wikiSpecial.Replace(s)
+	s = escapeEffects(s)
+	s = mdBold.ReplaceAllStringFunc(s, func(m string) string {
+		sm := mdBold.FindStringSubmatch(m)
+		if sm[1] != sm[3] {
+			return m
+		}
+		return boldMarker + sm[2] + boldMarker
+	})
+	s = mdItalic.ReplaceAllString(s, "${1}_${2}_")
+	s = strings.ReplaceAll(s, boldMarker, "*")
+	s = mdStrike.ReplaceAllString(s, "-${1}-")
+	s = htmlEffects.ReplaceAllStringFunc(s, func(m string) string {
+		sm := htmlEffects.FindStringSubmatch(m)
+		if sm[1] != sm[3] {
+			return m
+		}
+		marker := htmlEffectMap[sm[1]]
+		return marker + sm[2] + marker
+	})
+	s = mdLineBreak.ReplaceAllString(s, `\\`)
+	s = mdMention.ReplaceAllStringFunc(s, func(m string) string {
+		sm := mdMention.FindStringSubmatch(m)
+		if strings.Contains(sm[2], ":") {
+			// Jira Cloud account IDs, e.g. 557058:f58131cb-...
+			return sm[1] + "[~accountid:" + sm[2] + "]"
+		}
+		return sm[1] + "[~" + sm[2] + "]"
+	})
+
+	return protected.ReplaceAllStringFunc(s, func(m string) string {
+		var i int
+		fmt.Sscanf(protected.FindStringSubmatch(m)[1], "%d", &i)
+		return saved[i]
+	})
+}
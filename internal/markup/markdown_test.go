@@ -0,0 +1,81 @@
+package markup
+
+import "testing"
+
+func TestMarkdownToWiki(t *testing.T) {
+	tests := []struct {
+		name, md, want string
+	}{
+		{"headings", "# Title\n\n## Sub", "h1. Title\n\nh2. Sub"},
+		{"setext heading", "Title\n-----", "h2. Title"},
+		{"emphasis", "*em* **strong** ~~gone~~", "_em_ *strong* -gone-"},
+		{"nested lists", "- a\n  1. b\n  2. c\n- d", "* a\n*# b\n*# c\n* d"},
+		{"fence", "```go\nx := -y-\n```", "{code:go}\nx := -y-\n{code}"},
+		{"fence without language", "```\nraw\n```", "{noformat}\nraw\n{noformat}"},
+		{"table", "| A | B |\n| --- | --- |\n| 1 | 2 |", "||A||B||\n|1|2|"},
+		{"quote", "> quoted\n> text", "{quote}\nquoted\ntext\n{quote}"},
+		{"link", "[docs](https://x/y)", "[docs|https://x/y]"},
+		{"autolink", "<https://x/y>", "[https://x/y]"},
+		{"mention", "@jdoe", "[~jdoe]"},
+		{"account mention", "@557058:abc-123", "[~accountid:557058:abc-123]"},
+		{"brace and bracket", "a {x} [y]", `a \{x} \[y]`},
+		{"dash effect", "a -flag- b", `a \-flag- b`},
+		{"plus effect", "a +x+ b", `a \+x+ b`},
+		{"superscript effect", "a ^x^ b", `a \^x^ b`},
+		{"subscript effect", "a ~x~ b", `a \~x~ b`},
+		{"citation effect", "a ??x?? b", `a \??x?? b`},
+		{"markers in words", "well-known a+b x^2 snake_case", "well-known a+b x^2 snake_case"},
+		{"markers around spaces", "1 - 2 - 3 and a + b + c", "1 - 2 - 3 and a + b + c"},
+		{"code span", "`-x- {y}`", "{{-x- {y}}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToWiki(tt.md); got != tt.want {
+				t.Errorf("MarkdownToWiki(%q)\n got: %q\nwant: %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMarkdownRoundTrip converts Markdown to wiki markup and back. Where the
+// wiki markup cannot tell Markdown variants apart, want is the canonical
+// form; otherwise the text must come back unchanged.
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		name, md, want string
+	}{
+		{"headings", "# Title\n\n## Sub *em* **strong**", ""},
+		{"setext heading", "Title\n=====", "# Title"},
+		{"nested mixed lists", "- a\n  - b\n    1. c\n    2. d\n- e", ""},
+		{"ordered list", "1. one\n2. two\n   - x\n3. three", ""},
+		{"list markers", "* star\n+ plus", "- star\n- plus"},
+		{"fence", "```go\nfunc main() { a[0] = -x- }\n```", ""},
+		{"tilde fence", "~~~\nraw *text*\n~~~", "```\nraw *text*\n```"},
+		{"table", "| A | B |\n| --- | --- |\n| 1 | a \\| b |\n| `c` | [l](https://x) |", ""},
+		{"table without header", "|  |  |\n| --- | --- |\n| 1 | 2 |", ""},
+		{"quote", "> quoted **bold**\n> second", ""},
+		{"links", "See [docs](https://x/y) and <https://example.com>.", ""},
+		{"image", "![img.png](https://x/img.png)", ""},
+		{"mentions", "Ping @jdoe and @557058:abc-def", ""},
+		{"effect markers in text", "a -flag- b, +x+, x ^y^ z, a ~s~ b, ??c??", ""},
+		{"effect markers in lists", "- [link](https://x/a-b-c) -x-\n- @jdoe -y-", ""},
+		{"effect markers in emphasis", "*a -b- c*", ""},
+		{"braces and brackets", "a {x} and [x] and {{y}}", ""},
+		{"markers in words", "well-known 1 - 2 a--b snake_case_name x^2", ""},
+		{"strikethrough and code", "~~gone~~ and `-code-`", ""},
+		{"line break", "line<br>break", ""},
+		{"rule", "a\n\n---\n\nb", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == "" {
+				want = tt.md
+			}
+			wiki := MarkdownToWiki(tt.md)
+			if got := WikiToMarkdown(wiki); got != want {
+				t.Errorf("round trip of %q via %q\n got: %q\nwant: %q", tt.md, wiki, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+// Package markup converts between Jira wiki markup and Markdown.
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	heading      = regexp.MustCompile(`^h([1-6])\.\s*(.*)$`)
+	listItem     = regexp.MustCompile(`^([*#]+|-)\s+(.*)$`)
+	blockQuote   = regexp.MustCompile(`^bq\.\s*(.*)$`)
+	horizontal   = regexp.MustCompile(`^-{4,}$`)
+	blockMacro   = regexp.MustCompile(`^\{(code|noformat|quote|panel|info|note|warning|tip)(?::([^}]*))?\}`)
+	macroOptions = regexp.MustCompile(`([^|=]+)=([^|]*)`)
+)
+
+// calloutLabels names the admonition macros rendered as labelled quotes.
+var calloutLabels = map[string]string{
+	"info":    "Info",
+	"note":    "Note",
+	"warning": "Warning",
+	"tip":     "Tip",
+}
+
+// WikiToMarkdown converts Jira wiki markup to Markdown. It handles
+// headings, lists, tables, quotes, code, noformat, panel and admonition
+// blocks, horizontal rules, text effects, links, mentions and images.
+// Markup it does not recognise is passed through unchanged.
+func WikiToMarkdown(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	var out []string
+	var list listState
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if !listItem.MatchString(trimmed) || horizontal.MatchString(trimmed) {
+			list = listState{}
+		}
+
+		if m := blockMacro.FindStringSubmatch(trimmed); m != nil {
+			body, after, next := blockBody(lines, i, trimmed[len(m[0]):], m[1])
+			block := convertBlock(m[1], m[2], body)
+			if len(block) == 0 {
+				i = next
+				continue
+			}
+			// Blank lines keep a quote from running into the text around it.
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			out = append(out, block...)
+			if after = strings.TrimSpace(after); after != "" {
+				// Text after the closing tag is handled as a line of its own.
+				lines[next] = after
+				i = next - 1
+			} else {
+				i = next
+			}
+			if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+				out = append(out, "")
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "|") {
+			j := i
+			for j < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[j]), "|") {
+				j++
+			}
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			out = append(out, convertTable(lines[i:j])...)
+			i = j - 1
+			continue
+		}
+
+		switch {
+		case horizontal.MatchString(trimmed):
+			out = append(out, "---")
+		case heading.MatchString(trimmed):
+			m := heading.FindStringSubmatch(trimmed)
+			out = append(out, strings.Repeat("#", int(m[1][0]-'0'))+" "+convertInline(m[2]))
+		case blockQuote.MatchString(trimmed):
+			out = append(out, "> "+convertInline(blockQuote.FindStringSubmatch(trimmed)[1]))
+		case listItem.MatchString(trimmed):
+			m := listItem.FindStringSubmatch(trimmed)
+			out = append(out, list.item(m[1])+convertInline(m[2]))
+		default:
+			out = append(out, convertInline(line))
+		}
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// blockBody collects the lines of the block macro opened on line i, whose
+// first line starts with rest. It returns the body, any text following the
+// closing tag on its line, and the index of that line.
+func blockBody(lines []string, i int, rest, name string) (body []string, after string, end int) {
+	closing := "{" + name + "}"
+	for j := i; j < len(lines); j++ {
+		line := lines[j]
+		if j == i {
+			line = rest
+		}
+		if k := strings.Index(line, closing); k >= 0 {
+			if before := line[:k]; strings.TrimSpace(before) != "" || j != i {
+				body = append(body, before)
+			}
+			return trimBlankEdges(body), line[k+len(closing):], j
+		}
+		if j != i || strings.TrimSpace(line) != "" {
+			body = append(body, line)
+		}
+	}
+	// An unterminated block runs to the end of the text, as in Jira.
+	return trimBlankEdges(body), "", len(lines) - 1
+}
+
+// trimBlankEdges drops blank lines at the start and end of a block.
+func trimBlankEdges(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// convertBlock renders the body of a block macro.
+func convertBlock(name, params string, body []string) []string {
+	switch name {
+	case "code", "noformat":
+		lang := ""
+		if name == "code" {
+			lang = codeLanguage(params)
+		}
+		fence := codeFence(strings.Join(body, "\n"))
+		out := []string{fence + lang}
+		out = append(out, body...)
+		return append(out, fence)
+	default:
+		var out []string
+		title := macroOption(params, "title")
+		if label, ok := calloutLabels[name]; ok {
+			if title != "" {
+				label += ": " + title
+			}
+			title = label
+		}
+		if title != "" {
+			out = append(out, "> **"+convertInline(title)+"**")
+			if len(body) > 0 {
+				out = append(out, ">")
+			}
+		}
+		for _, line := range strings.Split(WikiToMarkdown(strings.Join(body, "\n")), "\n") {
+			if line == "" {
+				out = append(out, ">")
+			} else {
+				out = append(out, "> "+line)
+			}
+		}
+		if len(body) == 0 && title == "" {
+			return nil
+		}
+		return out
+	}
+}
+
+// codeLanguage returns the language of a {code} macro: the first parameter
+// without a value, or the language option.
+func codeLanguage(params string) string {
+	for _, p := range strings.Split(params, "|") {
+		p = strings.TrimSpace(p)
+		if p != "" && !strings.Contains(p, "=") {
+			return strings.ToLower(p)
+		}
+	}
+	return strings.ToLower(macroOption(params, "language"))
+}
+
+// macroOption returns the value of a key=value macro parameter.
+func macroOption(params, key string) string {
+	for _, m := range macroOptions.FindAllStringSubmatch(params, -1) {
+		if strings.TrimSpace(m[1]) == key {
+			return strings.TrimSpace(m[2])
+		}
+	}
+	return ""
+}
+
+// codeFence returns a backtick fence longer than any backtick run in content.
+func codeFence(content string) string {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	return fence
+}
+
+// listState tracks the open levels of a wiki list, so that ordered items
+// are numbered and nested items are indented under their parent's text.
+type listState struct {
+	kinds   []byte // wiki marker of each level: * or #
+	numbers []int  // last item number of each ordered level
+	widths  []int  // width of the Markdown marker of each level
+}
+
+// item returns the indentation and Markdown marker for a list item with the
+// given wiki markers, e.g. "*#".
+func (l *listState) item(markers string) string {
+	depth := len(markers)
+	kind := markers[depth-1]
+	if len(l.kinds) > depth || (len(l.kinds) == depth && l.kinds[depth-1] != kind) {
+		// Back to a shallower level, or a new list at this one.
+		keep := depth - 1
+		if len(l.kinds) >= depth && l.kinds[depth-1] == kind {
+			keep = depth
+		}
+		l.kinds, l.numbers, l.widths = l.kinds[:keep], l.numbers[:keep], l.widths[:keep]
+	}
+	for len(l.kinds) < depth {
+		// Levels skipped by the markup get a marker of their own kind.
+		l.kinds = append(l.kinds, markers[len(l.kinds)])
+		l.numbers = append(l.numbers, 0)
+		l.widths = append(l.widths, 2)
+	}
+
+	marker := "- "
+	if kind == '#' {
+		l.numbers[depth-1]++
+		marker = fmt.Sprintf("%d. ", l.numbers[depth-1])
+	}
+	l.widths[depth-1] = len(marker)
+
+	indent := 0
+	for _, w := range l.widths[:depth-1] {
+		indent += w
+	}
+	return strings.Repeat(" ", indent) + marker
+}
+
+// convertTable renders consecutive ||header|| and |cell| rows as a Markdown
+// table. A table without a header row gets an empty one, which Markdown
+// requires.
+func convertTable(lines []string) []string {
+	var rows [][]string
+	header := -1
+	width := 0
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if header < 0 && strings.HasPrefix(line, "||") {
+			header = i
+		}
+		cells := splitCells(line)
+		for j, c := range cells {
+			cell := strings.ReplaceAll(convertInline(strings.TrimSpace(c)), `\|`, "|")
+			cells[j] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		rows = append(rows, cells)
+		width = max(width, len(cells))
+	}
+
+	row := func(cells []string) string {
+		for len(cells) < width {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	separator := make([]string, width)
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	var out []string
+	if header == 0 {
+		out = append(out, row(rows[0]), row(separator))
+		rows = rows[1:]
+	} else {
+		out = append(out, row(make([]string, width)), row(separator))
+	}
+	for _, r := range rows {
+		out = append(out, row(r))
+	}
+	return out
+}
+
+// splitCells splits a table row on | and ||, ignoring escaped \| and
+// separators inside [links], {{monospace}} and {macros}.
+func splitCells(line string) []string {
+	var cells []string
+	var cell strings.Builder
+	depth := 0
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+			continue
+		case c == '[' || c == '{':
+			depth++
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+		case c == '|' && depth == 0:
+			if i > 0 {
+				cells = append(cells, cell.String())
+				cell.Reset()
+			}
+			if i+1 < len(line) && line[i+1] == '|' {
+				i++
+			}
+			continue
+		}
+		cell.WriteByte(c)
+	}
+	if strings.TrimSpace(cell.String()) != "" {
+		cells = append(cells, cell.String())
+	}
+	return cells
+}
+
+// DemoteHeadings moves the Markdown headings in md down by levels, so that a
+// converted text can be nested under the heading of the document it is
+// embedded in. Headings never go below level 6, and fenced code blocks are
+// left alone.
+func DemoteHeadings(md string, levels int) string {
+	lines := strings.Split(md, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, "`") == "" {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"):
+			fence = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, "`"))]
+		case strings.HasPrefix(line, "#"):
+			n := len(line) - len(strings.TrimLeft(line, "#"))
+			if n <= 6 && (n == len(line) || line[n] == ' ') {
+				lines[i] = strings.Repeat("#", min(n+levels, 6)) + line[n:]
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package markup
+
+import "testing"
+
+func TestWikiToMarkdown(t *testing.T) {
+	tests := []struct {
+		name, wiki, want string
+	}{
+		{"headings", "h1. Title\nh2. Heading _em_", "# Title\n## Heading *em*"},
+		{"nested mixed lists", "* a\n*# b\n*# c\n*#* d\n* e", "- a\n  1. b\n  2. c\n     - d\n- e"},
+		{"nested ordered lists", "# one\n# two\n## sub\n# three", "1. one\n2. two\n   1. sub\n3. three"},
+		{"list after paragraph restarts", "# a\n\n# b", "1. a\n\n1. b"},
+		{"code with language", "{code:java}\nint x = 1; // *not bold*\n{code}", "```java\nint x = 1; // *not bold*\n```"},
+		{"code with language option", "{code:language=python|title=x}\nprint(1)\n{code}", "```python\nprint(1)\n```"},
+		{"code containing a fence", "{code}\n```\n{code}", "````\n```\n````"},
+		{"noformat", "{noformat}\n_raw_ [link]\n{noformat}", "```\n_raw_ [link]\n```"},
+		{"panel with title", "{panel:title=Release notes|borderStyle=solid}\nh3. Fixed\n* one\n{panel}", "> **Release notes**\n>\n> ### Fixed\n> - one"},
+		{"admonition", "{info}Remember{info}", "> **Info**\n>\n> Remember"},
+		{"quote line", "bq. quoted *text*", "> quoted **text**"},
+		{"table with header", "||A||B||\n|1|2|", "| A | B |\n| --- | --- |\n| 1 | 2 |"},
+		{"table without header", "|1|2|\n|3|4|", "|  |  |\n| --- | --- |\n| 1 | 2 |\n| 3 | 4 |"},
+		{"table with escaped pipe", "||A||\n|a \\| b|", "| A |\n| --- |\n| a \\| b |"},
+		{"rule", "----", "---"},
+		{"link with text", "[docs|https://x/y]", "[docs](https://x/y)"},
+		{"bare link", "[https://x/y]", "<https://x/y>"},
+		{"issue link", "[text|PROJ-1]", "[text](PROJ-1)"},
+		{"anchor link", "[#anchor]", "anchor"},
+		{"attachment link", "[^file.txt]", "[file.txt](file.txt)"},
+		{"mention", "[~jdoe]", "@jdoe"},
+		{"account mention", "[~accountid:557058:abc]", "@557058:abc"},
+		{"image with options", "!img.png|thumbnail!", "![img.png](img.png)"},
+		{"image URL", "!https://x/a.png!", "![a.png](https://x/a.png)"},
+		{"text effects", "*bold* _em_ -strike- +ins+ ??cite?? {{mono}}", "**bold** *em* ~~strike~~ <ins>ins</ins> <cite>cite</cite> `mono`"},
+		{"effects inside words", "x*y*z a+b+c well-known-name", "x*y*z a+b+c well-known-name"},
+		{"snake case", "snake_case_name and file_name_v2", "snake_case_name and file_name_v2"},
+		{"effects around spaces", "a * b * c and 1 - 2 - 3", "a * b * c and 1 - 2 - 3"},
+		{"no effects in links", "[a_b_c|https://x/a_b_c]", "[a_b_c](https://x/a_b_c)"},
+		{"no effects in monospace", "{{-x- *y*}}", "`-x- *y*`"},
+		{"escapes", `\{x} \[y] \-z- \*w*`, `{x} [y] -z- \*w*`},
+		{"line break and color", `line\\break {color:red}red{color}`, "line<br>break red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WikiToMarkdown(tt.wiki); got != tt.want {
+				t.Errorf("WikiToMarkdown(%q)\n got: %q\nwant: %q", tt.wiki, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDemoteHeadings(t *testing.T) {
+	md := "# A\n\n```\n# not a heading\n```\n###### F\n#hashtag"
+	want := "### A\n\n```\n# not a heading\n```\n###### F\n#hashtag"
+	if got := DemoteHeadings(md, 2); got != want {
+		t.Errorf("DemoteHeadings\n got: %q\nwant: %q", got, want)
+	}
+}
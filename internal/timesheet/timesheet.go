@@ -0,0 +1,89 @@
+// Package timesheet aggregates Jira worklogs into a per-issue, per-day matrix.
+package timesheet
+
+import (
+	"time"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+)
+
+const dateLayout = "2006-01-02"
+
+// Sheet is the time one user logged in a date range, one row per issue and
+// one column per day.
+type Sheet struct {
+	User string
+	Days []time.Time
+	Rows []Row
+
+	// DayTotals holds the seconds logged per day, parallel to Days.
+	DayTotals []int
+	Total     int
+}
+
+// Row is the time logged on a single issue.
+type Row struct {
+	Key     string
+	Summary string
+	// Seconds holds the seconds logged per day, parallel to Sheet.Days.
+	Seconds []int
+	Total   int
+}
+
+// New returns an empty sheet for user covering from through to, inclusive.
+func New(user string, from, to time.Time) *Sheet {
+	s := &Sheet{User: user}
+	for d := truncateDay(from); !d.After(truncateDay(to)); d = d.AddDate(0, 0, 1) {
+		s.Days = append(s.Days, d)
+	}
+	s.DayTotals = make([]int, len(s.Days))
+	return s
+}
+
+// Add records the worklogs on issue written by the user (matched by
+// account ID or username) and started within the sheet's range. Other
+// entries are ignored. Rows are kept in the order their issues are added.
+func (s *Sheet) Add(issue *jira.Issue, worklogs []jira.Worklog) {
+	var row *Row
+	for _, wl := range worklogs {
+		if wl.Author == nil || wl.Author.ID() != s.User {
+			continue
+		}
+		started, err := time.Parse(jira.TimeLayout, wl.Started)
+		if err != nil {
+			continue
+		}
+		day := s.dayIndex(started.In(time.Local))
+		if day < 0 {
+			continue
+		}
+
+		if row == nil {
+			s.Rows = append(s.Rows, Row{
+				Key:     issue.Key,
+				Summary: issue.Fields.Summary,
+				Seconds: make([]int, len(s.Days)),
+			})
+			row = &s.Rows[len(s.Rows)-1]
+		}
+		row.Seconds[day] += wl.TimeSpentSeconds
+		row.Total += wl.TimeSpentSeconds
+		s.DayTotals[day] += wl.TimeSpentSeconds
+		s.Total += wl.TimeSpentSeconds
+	}
+}
+
+// dayIndex returns the column for t, or -1 if t is outside the range.
+func (s *Sheet) dayIndex(t time.Time) int {
+	day := truncateDay(t).Format(dateLayout)
+	for i, d := range s.Days {
+		if d.Format(dateLayout) == day {
+			return i
+		}
+	}
+	return -1
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,200 @@
+package timesheet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Write renders the sheet in the given format: text, markdown (or md), csv
+// or json.
+func Write(w io.Writer, s *Sheet, format string) error {
+	switch format {
+	case "text":
+		return WriteText(w, s)
+	case "markdown", "md":
+		return WriteMarkdown(w, s)
+	case "csv":
+		return WriteCSV(w, s)
+	case "json":
+		return WriteJSON(w, s)
+	default:
+		return fmt.Errorf("unknown output format: %q (use markdown, text, csv or json)", format)
+	}
+}
+
+// toHours converts seconds to hours, rounded to two decimals.
+func toHours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+// hours renders seconds as decimal hours, e.g. "1.5".
+func hours(seconds int) string {
+	return strconv.FormatFloat(toHours(seconds), 'f', -1, 64)
+}
+
+// cell is hours for table cells, blank for days without work.
+func cell(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return hours(seconds)
+}
+
+func (s *Sheet) dayHeaders() []string {
+	headers := make([]string, len(s.Days))
+	for i, d := range s.Days {
+		headers[i] = d.Format("Mon 01-02")
+	}
+	return headers
+}
+
+func (s *Sheet) period() string {
+	if len(s.Days) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s – %s", s.Days[0].Format(dateLayout), s.Days[len(s.Days)-1].Format(dateLayout))
+}
+
+// WriteText writes the sheet as an aligned plain text table.
+func WriteText(w io.Writer, s *Sheet) error {
+	fmt.Fprintf(w, "Timesheet for %s, %s\n\n", s.User, s.period())
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := append(append([]string{"KEY"}, s.dayHeaders()...), "TOTAL", "SUMMARY")
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, r := range s.Rows {
+		row := []string{r.Key}
+		for _, sec := range r.Seconds {
+			row = append(row, cell(sec))
+		}
+		row = append(row, hours(r.Total), r.Summary)
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	totals := []string{"TOTAL"}
+	for _, sec := range s.DayTotals {
+		totals = append(totals, cell(sec))
+	}
+	totals = append(totals, hours(s.Total))
+	fmt.Fprintln(tw, strings.Join(totals, "\t"))
+	return tw.Flush()
+}
+
+// WriteMarkdown writes the sheet as a Markdown table with a totals row.
+func WriteMarkdown(w io.Writer, s *Sheet) error {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# Timesheet for %s (%s)\n\n", s.User, s.period()))
+
+	headers := append(append([]string{"Key", "Summary"}, s.dayHeaders()...), "Total")
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat("---|", len(headers)) + "\n")
+	for _, r := range s.Rows {
+		row := []string{r.Key, strings.ReplaceAll(r.Summary, "|", "\\|")}
+		for _, sec := range r.Seconds {
+			row = append(row, cell(sec))
+		}
+		row = append(row, hours(r.Total))
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	totals := []string{"**Total**", ""}
+	for _, sec := range s.DayTotals {
+		totals = append(totals, cell(sec))
+	}
+	totals = append(totals, fmt.Sprintf("**%s**", hours(s.Total)))
+	b.WriteString("| " + strings.Join(totals, " | ") + " |\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCSV writes the sheet as CSV with one column per date and hours as
+// decimal numbers, ready for a spreadsheet.
+func WriteCSV(w io.Writer, s *Sheet) error {
+	cw := csv.NewWriter(w)
+	headers := []string{"Key", "Summary"}
+	for _, d := range s.Days {
+		headers = append(headers, d.Format(dateLayout))
+	}
+	headers = append(headers, "Total")
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+
+	for _, r := range s.Rows {
+		row := []string{r.Key, r.Summary}
+		for _, sec := range r.Seconds {
+			row = append(row, hours(sec))
+		}
+		row = append(row, hours(r.Total))
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	totals := []string{"Total", ""}
+	for _, sec := range s.DayTotals {
+		totals = append(totals, hours(sec))
+	}
+	totals = append(totals, hours(s.Total))
+	if err := cw.Write(totals); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type jsonSheet struct {
+	User   string      `json:"user"`
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Hours  float64     `json:"hours"`
+	Days   []jsonDay   `json:"days"`
+	Issues []jsonIssue `json:"issues"`
+}
+
+type jsonDay struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
+type jsonIssue struct {
+	Key     string             `json:"key"`
+	Summary string             `json:"summary"`
+	Hours   float64            `json:"hours"`
+	Days    map[string]float64 `json:"days"`
+}
+
+// WriteJSON writes the sheet as JSON with hours per day and per issue.
+func WriteJSON(w io.Writer, s *Sheet) error {
+	js := jsonSheet{
+		User:   s.User,
+		Hours:  toHours(s.Total),
+		Days:   []jsonDay{},
+		Issues: []jsonIssue{},
+	}
+	if len(s.Days) > 0 {
+		js.From = s.Days[0].Format(dateLayout)
+		js.To = s.Days[len(s.Days)-1].Format(dateLayout)
+	}
+	for i, d := range s.Days {
+		js.Days = append(js.Days, jsonDay{Date: d.Format(dateLayout), Hours: toHours(s.DayTotals[i])})
+	}
+	for _, r := range s.Rows {
+		ji := jsonIssue{Key: r.Key, Summary: r.Summary, Hours: toHours(r.Total), Days: map[string]float64{}}
+		for i, sec := range r.Seconds {
+			if sec > 0 {
+				ji.Days[s.Days[i].Format(dateLayout)] = toHours(sec)
+			}
+		}
+		js.Issues = append(js.Issues, ji)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(js)
+}
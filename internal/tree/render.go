@@ -0,0 +1,151 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// categoryLabels names the status categories in rolled-up counts, in the
+// order they are shown.
+var categoryLabels = []struct{ key, label string }{
+	{"new", "to do"},
+	{"indeterminate", "in progress"},
+	{"done", "done"},
+}
+
+// Write renders the tree in the given format: text, markdown or json.
+func Write(w io.Writer, root *Node, format string) error {
+	switch format {
+	case "text":
+		return WriteText(w, root)
+	case "markdown", "md":
+		return WriteMarkdown(w, root)
+	case "json":
+		return WriteJSON(w, root)
+	default:
+		return fmt.Errorf("unknown tree format: %q (use text, markdown or json)", format)
+	}
+}
+
+// label is the one-line description of a node shared by text and markdown.
+func label(n *Node) string {
+	s := n.Key
+	if n.Type != "" {
+		s += " [" + n.Type + "]"
+	}
+	if n.Summary != "" {
+		s += " " + n.Summary
+	}
+	if n.Status != "" {
+		s += " (" + n.Status + ")"
+	}
+	return s
+}
+
+// countSummary formats the rolled-up counts, e.g. "3 to do, 1 done".
+func countSummary(n *Node) string {
+	var parts []string
+	for _, c := range categoryLabels {
+		if count := n.Counts[c.key]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, c.label))
+		}
+	}
+	if count := n.Counts[""]; count > 0 {
+		parts = append(parts, fmt.Sprintf("%d other", count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WriteText renders the tree with box-drawing connectors.
+func WriteText(w io.Writer, root *Node) error {
+	var b strings.Builder
+	var walk func(n *Node, prefix, connector, childPrefix string)
+	walk = func(n *Node, prefix, connector, childPrefix string) {
+		line := prefix + connector + label(n)
+		if counts := countSummary(n); counts != "" {
+			line += " — " + counts
+		}
+		if n.Focus {
+			line += " ◀"
+		}
+		b.WriteString(line + "\n")
+		for i, c := range n.Children {
+			if i == len(n.Children)-1 {
+				walk(c, prefix+childPrefix, "└── ", "    ")
+			} else {
+				walk(c, prefix+childPrefix, "├── ", "│   ")
+			}
+		}
+	}
+	walk(root, "", "", "")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdown renders the tree as a nested list with the focus in bold.
+func WriteMarkdown(w io.Writer, root *Node) error {
+	var b strings.Builder
+	var walk func(n *Node, depth int)
+	walk = func(n *Node, depth int) {
+		line := label(n)
+		if n.Focus {
+			line = "**" + line + "**"
+		}
+		if counts := countSummary(n); counts != "" {
+			line += " — " + counts
+		}
+		b.WriteString(strings.Repeat("  ", depth) + "- " + line + "\n")
+		for _, c := range n.Children {
+			walk(c, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonNode struct {
+	Key      string         `json:"key"`
+	Type     string         `json:"type,omitempty"`
+	Summary  string         `json:"summary,omitempty"`
+	Status   string         `json:"status,omitempty"`
+	Category string         `json:"statusCategory,omitempty"`
+	Focus    bool           `json:"focus,omitempty"`
+	Counts   map[string]int `json:"counts,omitempty"`
+	Children []jsonNode     `json:"children,omitempty"`
+}
+
+func toJSONNode(n *Node) jsonNode {
+	jn := jsonNode{
+		Key:      n.Key,
+		Type:     n.Type,
+		Summary:  n.Summary,
+		Status:   n.Status,
+		Category: n.Category,
+		Focus:    n.Focus,
+	}
+	if len(n.Counts) > 0 {
+		jn.Counts = map[string]int{}
+		for key, count := range n.Counts {
+			if key == "" {
+				key = "other"
+			}
+			jn.Counts[key] += count
+		}
+	}
+	for _, c := range n.Children {
+		jn.Children = append(jn.Children, toJSONNode(c))
+	}
+	return jn
+}
+
+// WriteJSON renders the tree as nested nodes.
+func WriteJSON(w io.Writer, root *Node) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toJSONNode(root))
+}
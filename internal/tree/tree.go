@@ -0,0 +1,209 @@
+// Package tree builds the Parent Link → Epic → Story → Sub-task hierarchy
+// around an issue, with status counts rolled up per node.
+package tree
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bentsolheim/jira-cli/internal/jira"
+)
+
+// Node is an issue in the hierarchy.
+type Node struct {
+	Key     string
+	Type    string
+	Summary string
+	Status  string
+	// Category is the status category key: "new", "indeterminate" or "done".
+	Category string
+	// Focus marks the issue the tree was built for.
+	Focus    bool
+	Children []*Node
+	// Counts holds the number of descendants per status category key.
+	Counts map[string]int
+}
+
+// keysPerQuery caps the number of keys in one "in (...)" JQL clause.
+const keysPerQuery = 50
+
+func newNode(issue *jira.Issue) *Node {
+	n := &Node{Key: issue.Key, Summary: issue.Fields.Summary}
+	if t := issue.Fields.IssueType; t != nil {
+		n.Type = t.Name
+	}
+	if s := issue.Fields.Status; s != nil {
+		n.Status = s.Name
+		if s.Category != nil {
+			n.Category = s.Category.Key
+		}
+	}
+	return n
+}
+
+// Build fetches the hierarchy around key: the chain of parents (sub-task
+// parent, Epic Link, Parent Link) up to the root, and every descendant
+// (Parent Link children, epic children, sub-tasks). Siblings of the
+// ancestors are not included. It returns the root.
+func Build(ctx context.Context, client *jira.Client, key string) (*Node, error) {
+	issue, err := client.GetIssue(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", key, err)
+	}
+	fm, err := client.FieldMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	focus := newNode(issue)
+	focus.Focus = true
+	seen := map[string]bool{issue.Key: true}
+	if err := addDescendants(ctx, client, fm, focus, issue, seen); err != nil {
+		return nil, err
+	}
+
+	root := focus
+	for cur := issue; ; {
+		parentKey := parentOf(cur)
+		if parentKey == "" || seen[parentKey] {
+			break
+		}
+		seen[parentKey] = true
+		parent, err := client.GetIssueWithoutEpicChildren(ctx, parentKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent %s: %w", parentKey, err)
+		}
+		node := newNode(parent)
+		node.Children = []*Node{root}
+		root, cur = node, parent
+	}
+
+	rollup(root)
+	return root, nil
+}
+
+// parentOf returns the key of the issue one level up, if any.
+func parentOf(issue *jira.Issue) string {
+	switch {
+	case issue.Fields.Parent != nil:
+		return issue.Fields.Parent.Key
+	case issue.Fields.EpicLink != "":
+		return issue.Fields.EpicLink
+	default:
+		return issue.Fields.ParentLink
+	}
+}
+
+type pending struct {
+	node  *Node
+	issue *jira.Issue
+	// epicChild is set for issues found through an Epic Link, which are
+	// below the Parent Link hierarchy.
+	epicChild bool
+}
+
+// addDescendants expands the tree level by level. The children of epics are
+// found as configured with jira.WithEpicChildren, and the Parent Link
+// children of a whole level with one JQL search.
+func addDescendants(ctx context.Context, client *jira.Client, fm jira.FieldMap, node *Node, issue *jira.Issue, seen map[string]bool) error {
+	// Instances without a Parent Link field (Jira Cloud, team-managed
+	// projects) relate issues through parent instead.
+	parentField, err := fm.JQLName(jira.FieldParentLink)
+	if err != nil {
+		parentField = "parent"
+	}
+
+	level := []pending{{node: node, issue: issue, epicChild: issue.Fields.EpicLink != ""}}
+	for len(level) > 0 {
+		var next []pending
+		attach := func(parent *Node, child *jira.Issue, epicChild bool) {
+			if parent == nil || seen[child.Key] {
+				return
+			}
+			seen[child.Key] = true
+			n := newNode(child)
+			parent.Children = append(parent.Children, n)
+			next = append(next, pending{node: n, issue: child, epicChild: epicChild})
+		}
+
+		byKey := map[string]*Node{}
+		var parents []string
+		for _, p := range level {
+			byKey[p.issue.Key] = p.node
+			for i := range p.issue.Fields.Subtasks {
+				sub := &p.issue.Fields.Subtasks[i]
+				if !seen[sub.Key] {
+					seen[sub.Key] = true
+					p.node.Children = append(p.node.Children, newNode(sub))
+				}
+			}
+
+			t := p.issue.Fields.IssueType
+			switch {
+			case t != nil && t.Subtask:
+			case t != nil && jira.IsEpicType(t.Name):
+				children := p.issue.EpicChildren
+				if children == nil {
+					if children, err = client.GetEpicChildren(ctx, p.issue.Key); err != nil {
+						return err
+					}
+				}
+				for i := range children {
+					attach(p.node, &children[i], true)
+				}
+			case !p.epicChild:
+				parents = append(parents, p.issue.Key)
+			}
+		}
+
+		if len(parents) > 0 {
+			children, err := searchIn(ctx, client, parentField, parents)
+			if err != nil {
+				return err
+			}
+			for i := range children {
+				child := &children[i]
+				attach(byKey[linkOrParent(child.Fields.ParentLink, child)], child, false)
+			}
+		}
+		level = next
+	}
+	return nil
+}
+
+// linkOrParent returns link, or the issue's parent if link is empty, for
+// children found through the parent field.
+func linkOrParent(link string, issue *jira.Issue) string {
+	if link == "" && issue.Fields.Parent != nil {
+		return issue.Fields.Parent.Key
+	}
+	return link
+}
+
+// searchIn returns every issue whose field is one of keys.
+func searchIn(ctx context.Context, client *jira.Client, field string, keys []string) ([]jira.Issue, error) {
+	var issues []jira.Issue
+	for start := 0; start < len(keys); start += keysPerQuery {
+		end := min(start+keysPerQuery, len(keys))
+		jql := fmt.Sprintf("%s in (%s) ORDER BY key ASC", field, strings.Join(keys[start:end], ", "))
+		result, err := client.SearchAll(ctx, jql, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fetching children: %w", err)
+		}
+		issues = append(issues, result.Issues...)
+	}
+	return issues, nil
+}
+
+// rollup fills Counts for n and its descendants and returns n's counts.
+func rollup(n *Node) map[string]int {
+	n.Counts = map[string]int{}
+	for _, c := range n.Children {
+		n.Counts[c.Category]++
+		for category, count := range rollup(c) {
+			n.Counts[category] += count
+		}
+	}
+	return n.Counts
+}